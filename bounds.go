@@ -0,0 +1,81 @@
+package flextime
+
+import (
+	"fmt"
+	"time"
+)
+
+// Bounds limits results to plausible ones, so that e.g. epochs of a wrong unit or typos like 20222-01-01 fail
+// instead of surfacing downstream as times centuries away.
+// Limits which are zero are not applied.
+type Bounds struct {
+	// Min and Max are absolute limits, inclusive.
+	// The zero time.Time means unbounded, so it can not be a limit itself.
+	Min, Max time.Time
+	// Now returns the reference time of MaxPast and MaxFuture. time.Now is used if nil.
+	Now func() time.Time
+	// MaxPast and MaxFuture are limits relative to Now, inclusive.
+	MaxPast, MaxFuture time.Duration
+	// Continue makes Flextime try the next layout, and CombinedFlextime the next parser,
+	// instead of failing if a result is out of bounds.
+	Continue bool
+}
+
+// WithBounds makes Flextime and CombinedFlextime fail with *OutOfBoundsError if results are out of b.
+func WithBounds(b Bounds) CombinedOption {
+	return combinedOptionFunc(func(o *options) {
+		o.bounds = &b
+	})
+}
+
+// OutOfBoundsError is reported by WithBounds for a result out of bounds.
+type OutOfBoundsError struct {
+	Time time.Time
+	// Min and Max are the limits in effect. They are zero if not limited.
+	Min, Max time.Time
+}
+
+func (e *OutOfBoundsError) Error() string {
+	bound := func(t time.Time) string {
+		if t.IsZero() {
+			return "unbounded"
+		}
+		return t.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprintf(
+		"time %s is out of bounds: min is %s and max is %s",
+		e.Time.Format(time.RFC3339Nano), bound(e.Min), bound(e.Max),
+	)
+}
+
+// limits returns the limits in effect, taking the tighter of absolute and relative ones.
+func (b Bounds) limits() (min, max time.Time) {
+	min, max = b.Min, b.Max
+	if b.MaxPast == 0 && b.MaxFuture == 0 {
+		return min, max
+	}
+	now := nowOrDefault(b.Now)
+	if b.MaxPast != 0 {
+		if past := now.Add(-b.MaxPast); min.IsZero() || past.After(min) {
+			min = past
+		}
+	}
+	if b.MaxFuture != 0 {
+		if future := now.Add(b.MaxFuture); max.IsZero() || future.Before(max) {
+			max = future
+		}
+	}
+	return min, max
+}
+
+// checkBounds returns *OutOfBoundsError if t is out of the bounds.
+func (o *options) checkBounds(t time.Time) error {
+	if o.bounds == nil {
+		return nil
+	}
+	min, max := o.bounds.limits()
+	if (!min.IsZero() && t.Before(min)) || (!max.IsZero() && t.After(max)) {
+		return &OutOfBoundsError{Time: t, Min: min, Max: max}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package flextime_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBounds(t *testing.T) {
+	layouts, err := flextime.NewLayoutSet(`YYYY-MM-DD`)
+	require.NoError(t, err)
+	absolute := flextime.Bounds{
+		Min: time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+		Max: time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	f := flextime.NewFlextime(layouts, flextime.WithBounds(absolute))
+
+	parsed, err := f.Parse("2022-01-02")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC), parsed)
+
+	var boundsErr *flextime.OutOfBoundsError
+	for _, input := range []string{"1899-12-31", "2222-01-01"} {
+		_, err := f.Parse(input)
+		require.True(t, errors.As(err, &boundsErr), "input = %s, err = %v", input, err)
+		assert.Equal(t, absolute.Min, boundsErr.Min)
+		assert.Equal(t, absolute.Max, boundsErr.Max)
+	}
+
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	relative := flextime.NewFlextime(layouts, flextime.WithBounds(flextime.Bounds{
+		Min:       absolute.Min,
+		Now:       func() time.Time { return now },
+		MaxPast:   10 * 365 * 24 * time.Hour,
+		MaxFuture: 24 * time.Hour,
+	}))
+	_, err = relative.Parse("2022-06-02")
+	assert.NoError(t, err)
+	_, err = relative.Parse("2022-06-03")
+	require.True(t, errors.As(err, &boundsErr), "err = %v", err)
+	assert.Equal(t, now.Add(24*time.Hour), boundsErr.Max)
+	_, err = relative.Parse("2000-01-01")
+	require.True(t, errors.As(err, &boundsErr), "err = %v", err)
+	assert.Equal(t, now.Add(-10*365*24*time.Hour), boundsErr.Min)
+}
+
+func TestBoundsContinue(t *testing.T) {
+	yymmdd, err := flextime.NewLayoutSet(`YYMMDD`)
+	require.NoError(t, err)
+	ddmmyy, err := flextime.NewLayoutSet(`DDMMYY`)
+	require.NoError(t, err)
+	// DDMMYY comes first in the LayoutSet too.
+	parsers := []*flextime.Flextime{flextime.NewFlextime(ddmmyy), flextime.NewFlextime(yymmdd)}
+
+	// 010203 is 2003-02-01 in DDMMYY, and 2001-02-03 in YYMMDD.
+	bounds := flextime.Bounds{
+		Min: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		Max: time.Date(2003, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	var boundsErr *flextime.OutOfBoundsError
+	c := flextime.NewCombined(parsers, time.UnixMilli, flextime.WithBounds(bounds))
+	_, err = c.Parse("010203")
+	require.True(t, errors.As(err, &boundsErr), "err = %v", err)
+	assert.Equal(t, time.Date(2003, 2, 1, 0, 0, 0, 0, time.UTC), boundsErr.Time)
+
+	// Seconds passed where milliseconds are expected.
+	_, err = c.Parse(1666282966)
+	require.True(t, errors.As(err, &boundsErr), "err = %v", err)
+
+	f := flextime.NewFlextime(yymmdd.AddLayout(ddmmyy), flextime.WithBounds(bounds))
+	_, err = f.Parse("010203")
+	require.True(t, errors.As(err, &boundsErr), "err = %v", err)
+
+	bounds.Continue = true
+	c = flextime.NewCombined(parsers, time.UnixMilli, flextime.WithBounds(bounds))
+	parsed, err := c.Parse("010203")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC), parsed)
+
+	f = flextime.NewFlextime(yymmdd.AddLayout(ddmmyy), flextime.WithBounds(bounds))
+	parsed, err = f.Parse("010203")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC), parsed)
+}
+
+func TestBoundsRFC9557Suffix(t *testing.T) {
+	layouts, err := flextime.NewLayoutSet(`YYYY-MM-DDTHH:mm`)
+	require.NoError(t, err)
+	f := flextime.NewFlextime(layouts, flextime.WithRFC9557Suffix(), flextime.WithBounds(flextime.Bounds{
+		Max: time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC),
+	}))
+
+	// bounds apply to the time in the zone of the suffix.
+	parsed, err := f.Parse("2022-01-02T08:00[Asia/Tokyo]")
+	require.NoError(t, err)
+	assert.True(t, time.Date(2022, 1, 2, 8, 0, 0, 0, jst).Equal(parsed), "parsed = %s", parsed)
+
+	_, err = f.Parse("2022-01-01T20:00[America/New_York]")
+	var boundsErr *flextime.OutOfBoundsError
+	require.True(t, errors.As(err, &boundsErr), "err = %v", err)
+}
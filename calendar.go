@@ -0,0 +1,112 @@
+package flextime
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Calendar is a year numbering which differs from the Gregorian calendar by a fixed offset.
+type Calendar struct {
+	// Offset is added to Gregorian years to get years of the calendar.
+	Offset int
+	// Digits is the minimum width of formatted years.
+	Digits int
+}
+
+var (
+	// BuddhistCalendar is the Thai solar calendar, where 2022 is 2565.
+	BuddhistCalendar = Calendar{Offset: 543, Digits: 4}
+	// ROCCalendar is the Minguo calendar, where 2022 is 111.
+	ROCCalendar = Calendar{Offset: -1911, Digits: 3}
+)
+
+func (c Calendar) FromGregorian(year int) int {
+	return year + c.Offset
+}
+
+func (c Calendar) ToGregorian(year int) int {
+	return year - c.Offset
+}
+
+func (c Calendar) format(year int) string {
+	return fmt.Sprintf("%0*d", c.Digits, c.FromGregorian(year))
+}
+
+// gregorianCalendar is the calendar of EEEE tokens without WithCalendar.
+var gregorianCalendar = Calendar{Offset: 0, Digits: 4}
+
+// WithCalendar makes EEEE tokens, which NewExtendedLayoutSet recognizes, parse and format years of c.
+// YYYY, YY and other tokens keep Gregorian years. Without this option, EEEE is a Gregorian year.
+//
+// Since years of c may have other than 4 digits, EEEE accepts 1 to 4 digits.
+// If numeric tokens of fixed width directly follow it, as in EEEEMMDD, it takes the digits before
+// those they need, e.g. 1110102 is year 111 and 0102 with ROCCalendar.
+// If a numeric token of variable width follows, EEEE takes up to 4 digits.
+func WithCalendar(c Calendar) Option {
+	return optionFunc(func(o *options) {
+		o.calendar = &c
+	})
+}
+
+func (o *options) calendarOrDefault() Calendar {
+	if o.calendar != nil {
+		return *o.calendar
+	}
+	return gregorianCalendar
+}
+
+// parseCalendarYear parses a year of the calendar of WithCalendar and rewrites it into the Gregorian year.
+func parseCalendarYear(s *scanner) bool {
+	n, ok := calendarYearLen(s.rest, s.next)
+	if !ok {
+		return false
+	}
+	year, _ := strconv.Atoi(s.rest[:n])
+	year = s.opts.calendarOrDefault().ToGregorian(year)
+	if year < 0 || year > 9999 {
+		return false
+	}
+	s.emit("2006", fmt.Sprintf("%04d", year), n)
+	return true
+}
+
+func formatCalendarYear(o *options, t time.Time) string {
+	return o.calendarOrDefault().format(t.Year())
+}
+
+// calendarYearLen reports the length of a year of a non Gregorian calendar at the head of value.
+func calendarYearLen(value string, next []layoutChunk) (int, bool) {
+	digits, _ := numLen(value, 0, len(value))
+	if digits == 0 {
+		return 0, false
+	}
+	fixed, ok := followingFixedDigits(next)
+	if !ok {
+		return numLen(value, 1, 4)
+	}
+	n := digits - fixed
+	return n, 1 <= n && n <= 4
+}
+
+// followingFixedDigits sums widths of fixed width numeric std tokens at the head of next.
+// It reports false if a numeric token of variable width follows.
+func followingFixedDigits(next []layoutChunk) (int, bool) {
+	sum := 0
+	for _, c := range next {
+		if c.kind != stdChunk {
+			return sum, c.kind == literalChunk
+		}
+		switch c.value {
+		case "01", "02", "03", "04", "05", "06":
+			sum += 2
+		case "002":
+			sum += 3
+		case "2006":
+			sum += 4
+		default:
+			return sum, !isDigit(c.value, 0) && c.value[0] != '_'
+		}
+	}
+	return sum, true
+}
@@ -0,0 +1,92 @@
+package flextime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendar(t *testing.T) {
+	cases := []struct {
+		calendar flextime.Calendar
+		layout   string
+		input    string
+		expected time.Time
+		format   string
+	}{
+		{
+			calendar: flextime.BuddhistCalendar,
+			layout:   `DD/MM/EEEE`,
+			input:    "02/01/2565",
+			expected: time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC),
+			format:   "02/01/2565",
+		},
+		{
+			calendar: flextime.BuddhistCalendar,
+			layout:   `EEEEMMDD`,
+			input:    "25650102",
+			expected: time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC),
+			format:   "25650102",
+		},
+		{
+			calendar: flextime.ROCCalendar,
+			layout:   `EEEE/MM/DD`,
+			input:    "111/01/02",
+			expected: time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC),
+			format:   "111/01/02",
+		},
+		{
+			calendar: flextime.ROCCalendar,
+			layout:   `EEEE/M/D`,
+			input:    "99/1/2",
+			expected: time.Date(2010, 1, 2, 0, 0, 0, 0, time.UTC),
+			format:   "099/1/2",
+		},
+		{
+			calendar: flextime.ROCCalendar,
+			layout:   `EEEEMMDD`,
+			input:    "1110102",
+			expected: time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC),
+			format:   "1110102",
+		},
+		{
+			// YYYY keeps Gregorian years.
+			calendar: flextime.BuddhistCalendar,
+			layout:   `EEEE/YYYY-MM-DD`,
+			input:    "2565/2022-01-02",
+			expected: time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC),
+			format:   "2565/2022-01-02",
+		},
+	}
+
+	for _, testCase := range cases {
+		l, err := flextime.NewExtendedLayoutSet(testCase.layout)
+		require.NoError(t, err)
+		p := flextime.NewFlextime(l, flextime.WithCalendar(testCase.calendar))
+		parsed, err := p.ParseInLocation(testCase.input, time.UTC)
+		require.NoError(t, err, testCase.input)
+		require.True(t, testCase.expected.Equal(parsed), "expected = %s, actual = %s", testCase.expected, parsed)
+		require.Equal(t, testCase.format, p.Format(parsed))
+	}
+
+	l, err := flextime.NewExtendedLayoutSet(`EEEEMMDD`)
+	require.NoError(t, err)
+	_, err = flextime.NewFlextime(l, flextime.WithCalendar(flextime.ROCCalendar)).Parse("0102")
+	require.Error(t, err)
+
+	// Without WithCalendar, EEEE is a Gregorian year.
+	parsed, err := flextime.NewFlextime(l).ParseInLocation("20220102", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC), parsed)
+
+	// YYYY is not affected by WithCalendar.
+	l, err = flextime.NewSingleLayout(`YYYY-MM-DD`)
+	require.NoError(t, err)
+	p := flextime.NewFlextime(l, flextime.WithCalendar(flextime.BuddhistCalendar))
+	parsed, err = p.ParseInLocation("2022-01-02", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC), parsed)
+	require.Equal(t, "2022-01-02", p.Format(parsed))
+}
@@ -1,32 +1,84 @@
 package flextime
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"reflect"
 	"time"
 )
 
+// StringParser parses string values into time.Time. *Flextime implements it.
+type StringParser interface {
+	Parse(value string) (time.Time, error)
+	ParseInLocation(value string, loc *time.Location) (time.Time, error)
+}
+
 type CombinedFlextime struct {
-	parsers   []*Flextime
+	parsers   []StringParser
 	numParser func(int64) time.Time
+	opts      options
+}
+
+func NewCombined(parsers []*Flextime, numParser func(int64) time.Time, opts ...CombinedOption) *CombinedFlextime {
+	stringParsers := make([]StringParser, len(parsers))
+	for i, p := range parsers {
+		stringParsers[i] = p
+	}
+	return NewCombinedParsers(stringParsers, numParser, opts...)
 }
 
-func NewCombined(parsers []*Flextime, numParser func(int64) time.Time) *CombinedFlextime {
+// NewCombinedParsers is same as NewCombined but takes any StringParser,
+// e.g. DotNetJSONDate, in addition to *Flextime.
+func NewCombinedParsers(parsers []StringParser, numParser func(int64) time.Time, opts ...CombinedOption) *CombinedFlextime {
+	options := make([]Option, len(opts))
+	for i, opt := range opts {
+		options[i] = opt
+	}
 	return &CombinedFlextime{
 		parsers:   parsers,
 		numParser: numParser,
+		opts:      newOptions(options),
 	}
 }
 
 func (c *CombinedFlextime) Parse(v any) (time.Time, error) {
-	return c.parse(v, false, nil)
+	return c.finish(c.parse(v, false, nil))
 }
 
 func (c *CombinedFlextime) ParseInLocation(v any, loc *time.Location) (time.Time, error) {
-	return c.parse(v, true, loc)
+	return c.finish(c.parse(v, true, loc))
+}
+
+// finish checks bounds of a result of parsers and applies the output location and precision to it.
+func (c *CombinedFlextime) finish(t time.Time, err error) (time.Time, error) {
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := c.opts.checkBounds(t); err != nil {
+		return time.Time{}, err
+	}
+	return c.opts.finish(t), nil
+}
+
+// StringParser returns c as a StringParser, which parses string values only.
+func (c *CombinedFlextime) StringParser() StringParser {
+	return combinedStringParser{c}
+}
+
+type combinedStringParser struct {
+	c *CombinedFlextime
+}
+
+func (p combinedStringParser) Parse(value string) (time.Time, error) {
+	return p.c.finish(p.c.parseString(value, false, nil))
+}
+
+func (p combinedStringParser) ParseInLocation(value string, loc *time.Location) (time.Time, error) {
+	return p.c.finish(p.c.parseString(value, true, loc))
 }
 
 func (c *CombinedFlextime) parse(v any, inLoc bool, loc *time.Location) (time.Time, error) {
@@ -34,6 +86,9 @@ func (c *CombinedFlextime) parse(v any, inLoc bool, loc *time.Location) (time.Ti
 
 	switch rv.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if c.opts.floatParser != nil {
+			return c.opts.floatParser(float64(rv.Int())), nil
+		}
 		if c.numParser != nil {
 			return c.numParser(rv.Int()), nil
 		}
@@ -42,70 +97,95 @@ func (c *CombinedFlextime) parse(v any, inLoc bool, loc *time.Location) (time.Ti
 		if rv.Uint() > math.MaxInt64 {
 			return time.Time{}, &ValueOutOfRangeError{Value: rv.Uint()}
 		}
+		if c.opts.floatParser != nil {
+			return c.opts.floatParser(float64(rv.Uint())), nil
+		}
 		if c.numParser != nil {
 			return c.numParser(int64(rv.Uint())), nil
 		}
 		return time.Time{}, ErrEmptyNumParser
 	case reflect.Float32, reflect.Float64:
-		if c.numParser != nil {
-			// let's simply ignore fraction of number.
-			return c.numParser(int64(rv.Float())), nil
-		}
-		return time.Time{}, ErrEmptyNumParser
+		return c.parseFloat(rv.Float())
 	case reflect.String:
-		value := rv.String()
-		var lastErr error
-		for _, f := range c.parsers {
-			var parsed time.Time
-			var err error
-			if inLoc {
-				parsed, err = f.ParseInLocation(value, loc)
-			} else {
-				parsed, err = f.Parse(value)
-			}
-			if err != nil {
-				lastErr = err
-			} else {
-				return parsed, nil
-			}
-		}
-		return time.Time{}, lastErr
+		return c.parseString(rv.String(), inLoc, loc)
 	case reflect.Slice:
 		if bs, ok := v.([]byte); ok {
 			var jsonVar any
-			err := json.Unmarshal(bs, &jsonVar)
+			// UseNumber keeps integers larger than 2^53, e.g. FILETIME, as is.
+			dec := json.NewDecoder(bytes.NewReader(bs))
+			dec.UseNumber()
+			err := dec.Decode(&jsonVar)
+			if err == nil {
+				// More reports false for a closing bracket, so the rest must be nothing but io.EOF.
+				if _, tokErr := dec.Token(); tokErr != io.EOF {
+					err = errors.New("invalid character after top-level value")
+				}
+			}
 			if err != nil {
 				return time.Time{}, &UnmarshalError{Err: err}
 			}
 			switch x := jsonVar.(type) {
-			case float64:
-				if c.numParser != nil {
-					return c.numParser(int64(x)), nil
-				}
-				return time.Time{}, ErrEmptyNumParser
-			case string:
-				var lastErr error
-				for _, f := range c.parsers {
-					var parsed time.Time
-					var err error
-					if inLoc {
-						parsed, err = f.ParseInLocation(x, loc)
-					} else {
-						parsed, err = f.Parse(x)
-					}
-					if err != nil {
-						lastErr = err
-					} else {
-						return parsed, nil
+			case json.Number:
+				if i, err := x.Int64(); err == nil && c.opts.floatParser == nil {
+					if c.numParser != nil {
+						return c.numParser(i), nil
 					}
+					return time.Time{}, ErrEmptyNumParser
+				}
+				f, err := x.Float64()
+				if err != nil {
+					return time.Time{}, &UnmarshalError{Err: err}
 				}
-				return time.Time{}, lastErr
+				return c.parseFloat(f)
+			case string:
+				return c.parseString(x, inLoc, loc)
 			}
 		}
 	}
 	return time.Time{}, &UnsupportedTypeError{Typ: rv.Kind()}
 }
 
+func (c *CombinedFlextime) parseFloat(v float64) (time.Time, error) {
+	if c.opts.floatParser != nil {
+		return c.opts.floatParser(v), nil
+	}
+	if c.numParser != nil {
+		// let's simply ignore fraction of number.
+		return c.numParser(int64(v)), nil
+	}
+	return time.Time{}, ErrEmptyNumParser
+}
+
+func (c *CombinedFlextime) parseString(value string, inLoc bool, loc *time.Location) (time.Time, error) {
+	normalized := normalizedValue{original: value, value: value}
+	if c.opts.normalize {
+		normalized = normalize(value)
+	}
+
+	var lastErr error
+	for _, f := range c.parsers {
+		var parsed time.Time
+		var err error
+		if inLoc {
+			parsed, err = f.ParseInLocation(normalized.value, loc)
+		} else {
+			parsed, err = f.Parse(normalized.value)
+		}
+		if err == nil {
+			err = c.opts.checkBounds(parsed)
+		}
+		if err == nil {
+			return parsed, nil
+		}
+		var boundsErr *OutOfBoundsError
+		if errors.As(err, &boundsErr) && c.opts.bounds != nil && !c.opts.bounds.Continue {
+			return time.Time{}, err
+		}
+		lastErr = err
+	}
+	return time.Time{}, normalized.remapError(lastErr)
+}
+
 var ErrEmptyNumParser = errors.New("empty num parser")
 
 type ValueOutOfRangeError struct {
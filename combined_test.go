@@ -161,7 +161,14 @@ func TestCombinedError(t *testing.T) {
 	}
 
 	var unmarshalError *flextime.UnmarshalError
-	for _, nonUnmarshalable := range []any{[]byte("foobar"), []byte("123q")} {
+	for _, nonUnmarshalable := range []any{
+		[]byte("foobar"),
+		[]byte("123q"),
+		[]byte("123]"),
+		[]byte("123}"),
+		[]byte(`"2022-10-20T16:22:46.123+09:00"]`),
+		[]byte("123 456"),
+	} {
 		_, err = flextime.RFC3339orUnixMilli.ParseInLocation(nonUnmarshalable, nil)
 		assert.ErrorAs(t, err, &unmarshalError)
 	}
@@ -180,3 +187,28 @@ func TestCombinedError(t *testing.T) {
 	_, err = p.Parse(1666282966123)
 	assert.ErrorIs(t, err, flextime.ErrEmptyNumParser)
 }
+
+func TestCombinedOption(t *testing.T) {
+	for _, opt := range []flextime.Option{
+		flextime.WithNormalization(),
+		flextime.WithBounds(flextime.Bounds{}),
+		flextime.WithFloatParser(flextime.FromExcelSerial),
+		flextime.WithOutputLocation(time.UTC),
+		flextime.WithTruncate(time.Second),
+		flextime.WithRound(time.Second),
+	} {
+		_, ok := opt.(flextime.CombinedOption)
+		assert.True(t, ok)
+	}
+
+	// options which CombinedFlextime would ignore can not be passed to NewCombined.
+	for _, opt := range []flextime.Option{
+		flextime.WithLocale(nil),
+		flextime.WithCaseInsensitive(),
+		flextime.WithYearInference(flextime.YearInference{}),
+		flextime.WithStrictDate(),
+	} {
+		_, ok := opt.(flextime.CombinedOption)
+		assert.False(t, ok)
+	}
+}
@@ -0,0 +1,50 @@
+package flextime
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// WithTrailingComment makes Flextime ignore a parenthesized comment at the end of values and spaces before it,
+// e.g. (Japan Standard Time) of Mon Jan 02 2006 15:04:05 GMT+0900 (Japan Standard Time)
+// or (CEST) of Fri, 21 Nov 1997 09:55:06 +0200 (CEST).
+func WithTrailingComment() Option {
+	return optionFunc(func(o *options) {
+		o.trailingComment = true
+	})
+}
+
+// trimTrailingComment removes a trailing parenthesized comment and spaces before it from value.
+// Comments may nest. value is returned as is if it has no balanced comment at its end.
+func trimTrailingComment(value string) string {
+	trimmed := strings.TrimRight(value, " ")
+	if !strings.HasSuffix(trimmed, ")") {
+		return value
+	}
+	depth := 0
+	for i := len(trimmed) - 1; i >= 0; i-- {
+		switch trimmed[i] {
+		case ')':
+			depth++
+		case '(':
+			depth--
+			if depth == 0 {
+				return strings.TrimRight(trimmed[:i], " ")
+			}
+		}
+	}
+	return value
+}
+
+// remapTrimmedError rewrites time.ParseError reported against value without its comment or suffix
+// into one against original.
+func remapTrimmedError(original string, err error) error {
+	var parseErr *time.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Value == original {
+		return err
+	}
+	remapped := *parseErr
+	remapped.Value = original
+	return &remapped
+}
@@ -0,0 +1,197 @@
+package flextime
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateMath parses date math expressions of Elasticsearch and Grafana, e.g. now-1d/d or 2022-01-01||+1M/M.
+// It implements StringParser.
+//
+// An expression is an anchor, now or an absolute date followed by ||, and then chained operations:
+// +N<unit> and -N<unit> add or subtract N units (N defaults to 1), and /<unit> rounds to the unit.
+// Units are y (year), M (month), w (week), d (day), h or H (hour), m (minute) and s (second).
+// Weeks start on Monday. Adding months or years clamps the day to the end of the month.
+type DateMath struct {
+	// Now returns the reference time. time.Now is used if nil.
+	Now func() time.Time
+	// Location is where anchors are parsed and dates are rounded. The location of Now is used if nil.
+	// ParseInLocation overrides it.
+	Location *time.Location
+	// Anchor parses absolute anchors. A Flextime of ISO8601 is used if nil.
+	Anchor StringParser
+	// RoundUp makes /<unit> round to the last nanosecond of the unit instead of its start,
+	// e.g. now/d is 23:59:59.999999999 of today. Use it for inclusive upper bounds of ranges.
+	RoundUp bool
+}
+
+const dateMathLayout = "date math"
+
+var defaultDateMathAnchor = NewFlextime(ISO8601)
+
+func (d DateMath) Parse(value string) (time.Time, error) {
+	loc := d.Location
+	if loc == nil {
+		loc = d.now().Location()
+	}
+	return d.ParseInLocation(value, loc)
+}
+
+func (d DateMath) ParseInLocation(value string, loc *time.Location) (time.Time, error) {
+	parseErr := func(elem, msg string) error {
+		return &time.ParseError{
+			Layout:     dateMathLayout,
+			Value:      value,
+			LayoutElem: dateMathLayout,
+			ValueElem:  elem,
+			Message:    ": " + msg,
+		}
+	}
+
+	var t time.Time
+	var math string
+	if strings.HasPrefix(value, "now") {
+		t = d.now().In(loc)
+		math = value[len("now"):]
+	} else {
+		anchor, rest, ok := strings.Cut(value, "||")
+		if !ok {
+			return time.Time{}, parseErr(value, "neither now nor an anchor followed by ||")
+		}
+		anchorParser := d.Anchor
+		if anchorParser == nil {
+			anchorParser = defaultDateMathAnchor
+		}
+		var err error
+		t, err = anchorParser.ParseInLocation(anchor, loc)
+		if err != nil {
+			return time.Time{}, err
+		}
+		math = rest
+	}
+
+	for len(math) > 0 {
+		op := math[0]
+		switch op {
+		case '+', '-':
+			n, ok := numLen(math[1:], 0, 9)
+			if !ok || 1+n >= len(math) {
+				return time.Time{}, parseErr(math, "missing unit")
+			}
+			amount := 1
+			if n > 0 {
+				amount, _ = strconv.Atoi(math[1 : 1+n])
+			}
+			if op == '-' {
+				amount = -amount
+			}
+			added, ok, overflow := addDateMathUnit(t, math[1+n], amount)
+			if overflow {
+				return time.Time{}, parseErr(math, "amount out of range")
+			}
+			if !ok {
+				return time.Time{}, parseErr(math[1+n:], "unknown unit")
+			}
+			t = added
+			math = math[2+n:]
+		case '/':
+			if len(math) < 2 {
+				return time.Time{}, parseErr(math, "missing unit")
+			}
+			rounded, ok := roundDateMathUnit(t, math[1], d.RoundUp)
+			if !ok {
+				return time.Time{}, parseErr(math[1:], "unknown unit")
+			}
+			t = rounded
+			math = math[2:]
+		default:
+			return time.Time{}, parseErr(math, "unknown operator")
+		}
+	}
+	return t, nil
+}
+
+func (d DateMath) now() time.Time {
+	if d.Now != nil {
+		return d.Now()
+	}
+	return time.Now()
+}
+
+// addDateMathUnit adds n units to t. overflow is true if n of unit does not fit in time.Duration.
+func addDateMathUnit(t time.Time, unit byte, n int) (added time.Time, ok bool, overflow bool) {
+	addDuration := func(d time.Duration) (time.Time, bool, bool) {
+		if int64(n) > math.MaxInt64/int64(d) || int64(n) < math.MinInt64/int64(d) {
+			return time.Time{}, false, true
+		}
+		return t.Add(time.Duration(n) * d), true, false
+	}
+
+	switch unit {
+	case 'y':
+		return addMonthsClamped(t, 12*n), true, false
+	case 'M':
+		return addMonthsClamped(t, n), true, false
+	case 'w':
+		return t.AddDate(0, 0, 7*n), true, false
+	case 'd':
+		return t.AddDate(0, 0, n), true, false
+	case 'h', 'H':
+		return addDuration(time.Hour)
+	case 'm':
+		return addDuration(time.Minute)
+	case 's':
+		return addDuration(time.Second)
+	}
+	return time.Time{}, false, false
+}
+
+// addMonthsClamped adds n months to t. Unlike time.Time.AddDate, the day is clamped to the end of the month
+// as Elasticsearch does, e.g. 2022-01-31 plus a month is 2022-02-28.
+func addMonthsClamped(t time.Time, n int) time.Time {
+	y, mo, d := t.Date()
+	h, mi, s := t.Clock()
+	first := time.Date(y, mo+time.Month(n), 1, h, mi, s, t.Nanosecond(), t.Location())
+	if last := daysIn(first.Year(), first.Month()); d > last {
+		d = last
+	}
+	return first.AddDate(0, 0, d-1)
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func roundDateMathUnit(t time.Time, unit byte, up bool) (time.Time, bool) {
+	y, mo, d := t.Date()
+	h, mi, s := t.Clock()
+	loc := t.Location()
+
+	var start time.Time
+	switch unit {
+	case 'y':
+		start = time.Date(y, time.January, 1, 0, 0, 0, 0, loc)
+	case 'M':
+		start = time.Date(y, mo, 1, 0, 0, 0, 0, loc)
+	case 'w':
+		sinceMonday := (int(t.Weekday()) + 6) % 7
+		start = time.Date(y, mo, d-sinceMonday, 0, 0, 0, 0, loc)
+	case 'd':
+		start = time.Date(y, mo, d, 0, 0, 0, 0, loc)
+	case 'h', 'H':
+		start = time.Date(y, mo, d, h, 0, 0, 0, loc)
+	case 'm':
+		start = time.Date(y, mo, d, h, mi, 0, 0, loc)
+	case 's':
+		start = time.Date(y, mo, d, h, mi, s, 0, loc)
+	default:
+		return time.Time{}, false
+	}
+	if !up {
+		return start, true
+	}
+	next, _, _ := addDateMathUnit(start, unit, 1)
+	return next.Add(-time.Nanosecond), true
+}
@@ -0,0 +1,75 @@
+package flextime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateMath(t *testing.T) {
+	// Wednesday
+	now := time.Date(2022, 1, 5, 15, 4, 5, 123, time.UTC)
+	down := flextime.DateMath{Now: func() time.Time { return now }}
+	up := flextime.DateMath{Now: func() time.Time { return now }, RoundUp: true}
+
+	for _, testCase := range []struct {
+		input        string
+		expectedDown time.Time
+		expectedUp   time.Time
+	}{
+		{"now", now, now},
+		{"now-1d", time.Date(2022, 1, 4, 15, 4, 5, 123, time.UTC), time.Date(2022, 1, 4, 15, 4, 5, 123, time.UTC)},
+		{"now-1d/d", time.Date(2022, 1, 4, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 4, 23, 59, 59, 999999999, time.UTC)},
+		{"now/w", time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 9, 23, 59, 59, 999999999, time.UTC)},
+		{"now+h/H", time.Date(2022, 1, 5, 16, 0, 0, 0, time.UTC), time.Date(2022, 1, 5, 16, 59, 59, 999999999, time.UTC)},
+		{"now/y+2M-30m", time.Date(2022, 2, 28, 23, 30, 0, 0, time.UTC), time.Date(2023, 2, 28, 23, 29, 59, 999999999, time.UTC)},
+		{"2022-01-01||+1M/M", time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 2, 28, 23, 59, 59, 999999999, time.UTC)},
+		{"2022-01-31T10:00:00Z||", time.Date(2022, 1, 31, 10, 0, 0, 0, time.UTC), time.Date(2022, 1, 31, 10, 0, 0, 0, time.UTC)},
+		{"2022-01-31T10:00:00Z||+1M", time.Date(2022, 2, 28, 10, 0, 0, 0, time.UTC), time.Date(2022, 2, 28, 10, 0, 0, 0, time.UTC)},
+		{"2022-01-31T10:00:00Z||-10s/m", time.Date(2022, 1, 31, 9, 59, 0, 0, time.UTC), time.Date(2022, 1, 31, 9, 59, 59, 999999999, time.UTC)},
+	} {
+		parsed, err := down.Parse(testCase.input)
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, testCase.expectedDown, parsed, testCase.input)
+		parsed, err = up.Parse(testCase.input)
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, testCase.expectedUp, parsed, testCase.input)
+	}
+
+	// rounded in the location.
+	parsed, err := down.ParseInLocation("now/d", jst)
+	require.NoError(t, err)
+	require.True(t, time.Date(2022, 1, 6, 0, 0, 0, 0, jst).Equal(parsed), "actual = %s", parsed)
+
+	// anchors are parsed by any LayoutSet.
+	parsed, err = flextime.DateMath{Anchor: flextime.NewFlextime(flextime.CommonLogFormat)}.Parse("10/Oct/2000:13:55:36 -0700||/d")
+	require.NoError(t, err)
+	require.True(t, time.Date(2000, 10, 10, 0, 0, 0, 0, time.FixedZone("", -7*60*60)).Equal(parsed), "actual = %s", parsed)
+
+	for _, invalid := range []string{"", "2022-01-01", "now-", "now-1", "now-1q", "now/", "now/q", "now*2d", "2022-13-01||"} {
+		_, err := down.Parse(invalid)
+		var parseErr *time.ParseError
+		require.ErrorAs(t, err, &parseErr, invalid)
+	}
+
+	// amounts which do not fit in time.Duration are errors, not wrapped around.
+	for _, overflow := range []string{"now+3000000h", "now-3000000h", "now+999999999h", "now-999999999h"} {
+		_, err := down.Parse(overflow)
+		var parseErr *time.ParseError
+		require.ErrorAs(t, err, &parseErr, overflow)
+		require.Contains(t, parseErr.Message, "amount out of range", overflow)
+	}
+	parsed, err = down.Parse("now+2562047h")
+	require.NoError(t, err)
+	require.Equal(t, now.Add(2562047*time.Hour), parsed)
+	parsed, err = down.Parse("now-2562047h")
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-2562047*time.Hour), parsed)
+
+	c := flextime.NewCombinedParsers([]flextime.StringParser{down}, time.UnixMilli)
+	parsed, err = c.Parse("now-1d/d")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2022, 1, 4, 0, 0, 0, 0, time.UTC), parsed)
+}
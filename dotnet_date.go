@@ -0,0 +1,71 @@
+package flextime
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DotNetJSONDate parses the date format of DataContractJsonSerializer and ASP.NET AJAX of .NET,
+// e.g. /Date(1650000000000+0900)/, where 1650000000000 is unix milli and +0900 is the offset of the local time.
+// The offset is optional. A value without an offset is taken in UTC, or in loc by ParseInLocation.
+var DotNetJSONDate StringParser = dotNetJSONDate{}
+
+const dotNetJSONDateLayout = `/Date(ms±hhmm)/`
+
+type dotNetJSONDate struct{}
+
+func (p dotNetJSONDate) Parse(value string) (time.Time, error) {
+	return p.ParseInLocation(value, time.UTC)
+}
+
+func (dotNetJSONDate) ParseInLocation(value string, loc *time.Location) (time.Time, error) {
+	parseErr := func(elem, msg string) error {
+		return &time.ParseError{
+			Layout:     dotNetJSONDateLayout,
+			Value:      value,
+			LayoutElem: elem,
+			ValueElem:  value,
+			Message:    ": " + msg,
+		}
+	}
+
+	// Escaped slashes are often left as is when the value is taken out of JSON without decoding.
+	body := strings.ReplaceAll(value, `\/`, `/`)
+	if !strings.HasPrefix(body, "/Date(") || !strings.HasSuffix(body, ")/") {
+		return time.Time{}, parseErr(dotNetJSONDateLayout, "not wrapped by /Date( and )/")
+	}
+	body = body[len("/Date(") : len(body)-len(")/")]
+
+	msLen := len(body)
+	for i := 1; i < len(body); i++ {
+		if body[i] == '+' || body[i] == '-' {
+			msLen = i
+			break
+		}
+	}
+	ms, err := strconv.ParseInt(body[:msLen], 10, 64)
+	if err != nil {
+		return time.Time{}, parseErr("ms", "invalid unix milli")
+	}
+	t := time.UnixMilli(ms)
+
+	zone := body[msLen:]
+	if zone == "" {
+		return t.In(loc), nil
+	}
+	n, ok := numLen(zone[1:], 4, 4)
+	if !ok || n != len(zone)-1 {
+		return time.Time{}, parseErr("±hhmm", "invalid offset")
+	}
+	hh, _ := strconv.Atoi(zone[1:3])
+	mm, _ := strconv.Atoi(zone[3:5])
+	if hh > 23 || mm > 59 {
+		return time.Time{}, parseErr("±hhmm", "offset out of range")
+	}
+	offset := (hh*60 + mm) * 60
+	if zone[0] == '-' {
+		offset = -offset
+	}
+	return t.In(time.FixedZone("", offset)), nil
+}
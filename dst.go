@@ -0,0 +1,111 @@
+package flextime
+
+import (
+	"fmt"
+	"time"
+)
+
+// DSTPolicy decides how local times without an offset are resolved
+// if they fall in a transition of the location, e.g. daylight saving time.
+type DSTPolicy int
+
+const (
+	// DSTDefault leaves local times to the time package, which does not guarantee which offset is taken.
+	DSTDefault DSTPolicy = iota
+	// DSTEarlier takes the earlier instant of an ambiguous local time.
+	// A nonexistent local time is taken with the offset after the transition,
+	// which moves it backward by the length of the gap, e.g. 02:30 is 01:30 EST when clocks jump from 02:00 EST to 03:00 EDT.
+	DSTEarlier
+	// DSTLater takes the later instant of an ambiguous local time.
+	// A nonexistent local time is taken with the offset before the transition,
+	// which moves it forward by the length of the gap, e.g. 02:30 is 03:30 EDT.
+	DSTLater
+	// DSTReject fails to parse nonexistent and ambiguous local times with *LocalTimeError.
+	DSTReject
+	// DSTShiftForward takes the first instant after the gap for a nonexistent local time, e.g. 02:30 is 03:00 EDT,
+	// and the earlier instant of an ambiguous local time.
+	DSTShiftForward
+)
+
+// WithDSTPolicy makes Flextime resolve local times in transitions of the location by p.
+// It applies to values without an offset parsed by ParseInLocation or with a zzzz token.
+func WithDSTPolicy(p DSTPolicy) Option {
+	return optionFunc(func(o *options) {
+		o.dstPolicy = p
+	})
+}
+
+// LocalTimeError is reported by DSTReject for a local time which does not exist or is ambiguous in Location.
+type LocalTimeError struct {
+	// Local is the local time as a time in UTC.
+	Local    time.Time
+	Location *time.Location
+	// Ambiguous is true if the local time occurs twice, e.g. by falling back from daylight saving time,
+	// and false if it is skipped, e.g. by springing forward.
+	Ambiguous bool
+}
+
+func (e *LocalTimeError) Error() string {
+	kind := "nonexistent"
+	if e.Ambiguous {
+		kind = "ambiguous"
+	}
+	return fmt.Sprintf("%s local time %s in %s", kind, e.Local.Format("2006-01-02T15:04:05.999999999"), e.Location)
+}
+
+// resolveLocal returns the wall clock of w, whose location is ignored, in loc according to policy.
+func resolveLocal(w time.Time, loc *time.Location, policy DSTPolicy) (time.Time, error) {
+	y, mo, d := w.Date()
+	h, mi, s := w.Clock()
+	if policy == DSTDefault {
+		return time.Date(y, mo, d, h, mi, s, w.Nanosecond(), loc), nil
+	}
+	local := time.Date(y, mo, d, h, mi, s, w.Nanosecond(), time.UTC)
+
+	// Offsets a day before and after surely cover a transition around the local time.
+	_, offsetBefore := local.Add(-24 * time.Hour).In(loc).Zone()
+	_, offsetAfter := local.Add(24 * time.Hour).In(loc).Zone()
+	withOffset := func(offset int) (time.Time, bool) {
+		t := local.Add(-time.Duration(offset) * time.Second).In(loc)
+		_, actual := t.Zone()
+		return t, actual == offset
+	}
+	before, beforeOk := withOffset(offsetBefore)
+	after, afterOk := withOffset(offsetAfter)
+
+	switch {
+	case offsetBefore == offsetAfter || beforeOk != afterOk:
+		if beforeOk {
+			return before, nil
+		}
+		if afterOk {
+			return after, nil
+		}
+		// transitions within the day, which is out of the assumption.
+		return time.Date(y, mo, d, h, mi, s, w.Nanosecond(), loc), nil
+	case beforeOk && afterOk:
+		earlier, later := before, after
+		if later.Before(earlier) {
+			earlier, later = later, earlier
+		}
+		switch policy {
+		case DSTLater:
+			return later, nil
+		case DSTReject:
+			return time.Time{}, &LocalTimeError{Local: local, Location: loc, Ambiguous: true}
+		}
+		return earlier, nil
+	}
+
+	// in a gap.
+	switch policy {
+	case DSTEarlier:
+		return local.Add(-time.Duration(offsetAfter) * time.Second).In(loc), nil
+	case DSTLater:
+		return local.Add(-time.Duration(offsetBefore) * time.Second).In(loc), nil
+	case DSTReject:
+		return time.Time{}, &LocalTimeError{Local: local, Location: loc}
+	}
+	start, _ := local.Add(-time.Duration(offsetBefore) * time.Second).In(loc).ZoneBounds()
+	return start, nil
+}
@@ -0,0 +1,98 @@
+package flextime_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDSTPolicy(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	edt := time.FixedZone("EDT", -4*60*60)
+	est := time.FixedZone("EST", -5*60*60)
+
+	layouts, err := flextime.NewLayoutSet(`YYYY-MM-DD HH:mm:ss[Z]`)
+	require.NoError(t, err)
+
+	const (
+		gap       = "2022-03-13 02:30:00"
+		ambiguous = "2022-11-06 01:30:00"
+		regular   = "2022-07-01 12:00:00"
+	)
+	for _, testCase := range []struct {
+		policy    flextime.DSTPolicy
+		gap       time.Time
+		ambiguous time.Time
+	}{
+		{
+			policy:    flextime.DSTEarlier,
+			gap:       time.Date(2022, 3, 13, 1, 30, 0, 0, est),
+			ambiguous: time.Date(2022, 11, 6, 1, 30, 0, 0, edt),
+		},
+		{
+			policy:    flextime.DSTLater,
+			gap:       time.Date(2022, 3, 13, 3, 30, 0, 0, edt),
+			ambiguous: time.Date(2022, 11, 6, 1, 30, 0, 0, est),
+		},
+		{
+			policy:    flextime.DSTShiftForward,
+			gap:       time.Date(2022, 3, 13, 3, 0, 0, 0, edt),
+			ambiguous: time.Date(2022, 11, 6, 1, 30, 0, 0, edt),
+		},
+	} {
+		f := flextime.NewFlextime(layouts, flextime.WithDSTPolicy(testCase.policy))
+
+		parsed, err := f.ParseInLocation(gap, newYork)
+		require.NoError(t, err)
+		assert.True(t, testCase.gap.Equal(parsed), "policy = %d, parsed = %s", testCase.policy, parsed)
+		assert.Equal(t, newYork, parsed.Location())
+
+		parsed, err = f.ParseInLocation(ambiguous, newYork)
+		require.NoError(t, err)
+		assert.True(t, testCase.ambiguous.Equal(parsed), "policy = %d, parsed = %s", testCase.policy, parsed)
+
+		parsed, err = f.ParseInLocation(regular, newYork)
+		require.NoError(t, err)
+		assert.True(t, time.Date(2022, 7, 1, 12, 0, 0, 0, edt).Equal(parsed))
+
+		// Values with an offset are not affected.
+		parsed, err = f.ParseInLocation("2022-03-13 02:30:00-05:00", newYork)
+		require.NoError(t, err)
+		assert.True(t, time.Date(2022, 3, 13, 2, 30, 0, 0, est).Equal(parsed))
+	}
+
+	f := flextime.NewFlextime(layouts, flextime.WithDSTPolicy(flextime.DSTReject))
+	for _, testCase := range []struct {
+		input     string
+		ambiguous bool
+	}{
+		{gap, false},
+		{ambiguous, true},
+	} {
+		_, err := f.ParseInLocation(testCase.input, newYork)
+		var localErr *flextime.LocalTimeError
+		require.True(t, errors.As(err, &localErr), "err = %v", err)
+		assert.Equal(t, testCase.ambiguous, localErr.Ambiguous)
+		assert.Equal(t, newYork, localErr.Location)
+	}
+	parsed, err := f.ParseInLocation(regular, newYork)
+	require.NoError(t, err)
+	assert.True(t, time.Date(2022, 7, 1, 12, 0, 0, 0, edt).Equal(parsed))
+}
+
+func TestDSTPolicyZoneID(t *testing.T) {
+	layouts, err := flextime.NewExtendedLayoutSet(`YYYY-MM-DD HH:mm:ss zzzz`)
+	require.NoError(t, err)
+	f := flextime.NewFlextime(layouts, flextime.WithDSTPolicy(flextime.DSTReject))
+
+	_, err = f.Parse("2022-03-13 02:30:00 America/New_York")
+	var localErr *flextime.LocalTimeError
+	require.True(t, errors.As(err, &localErr), "err = %v", err)
+	assert.False(t, localErr.Ambiguous)
+}
@@ -0,0 +1,246 @@
+package flextime
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration is a duration of ISO 8601, e.g. P1Y2M3DT4H5M6.5S or P2W.
+// Components are kept as written since years, months and days do not have a fixed length.
+// Unlike ISO 8601, which allows a fraction on the lowest-order component, only seconds may have a fraction,
+// since other components could not keep it; P0.5D or PT0.5H is rejected, and PT12H or PT30M should be written instead.
+type Duration struct {
+	// Negative is true for durations written with a leading minus sign, e.g. -P1D.
+	Negative bool
+	Years    int
+	Months   int
+	Weeks    int
+	Days     int
+	Hours    int
+	Minutes  int
+	// Seconds is seconds with a fraction.
+	Seconds time.Duration
+}
+
+const durationLayout = "ISO 8601 duration"
+
+// ParseDuration parses a duration of ISO 8601. Only seconds may have a fraction, separated by . or ,.
+// Hours, minutes and seconds are rejected if they overflow time.Duration.
+func ParseDuration(value string) (Duration, error) {
+	parseErr := func(elem, msg string) error {
+		return &time.ParseError{
+			Layout:     durationLayout,
+			Value:      value,
+			LayoutElem: durationLayout,
+			ValueElem:  elem,
+			Message:    ": " + msg,
+		}
+	}
+
+	var d Duration
+	rest := value
+	switch {
+	case strings.HasPrefix(rest, "-"):
+		d.Negative = true
+		rest = rest[1:]
+	case strings.HasPrefix(rest, "+"):
+		rest = rest[1:]
+	}
+	if !strings.HasPrefix(rest, "P") {
+		return Duration{}, parseErr(rest, "missing P")
+	}
+	rest = rest[1:]
+
+	inTime := false
+	// designators which may come next, in order.
+	designators := "YMWD"
+	found := false
+	for len(rest) > 0 {
+		if rest[0] == 'T' {
+			if inTime {
+				return Duration{}, parseErr(rest, "duplicate T")
+			}
+			inTime = true
+			designators = "HMS"
+			rest = rest[1:]
+			if len(rest) == 0 {
+				return Duration{}, parseErr(rest, "missing time components after T")
+			}
+			continue
+		}
+
+		n, _ := numLen(rest, 0, len(rest))
+		if n == 0 {
+			return Duration{}, parseErr(rest, "missing number")
+		}
+		num, err := strconv.Atoi(rest[:n])
+		if err != nil {
+			return Duration{}, parseErr(rest, "number out of range")
+		}
+		var frac string
+		if n < len(rest) && commaOrPeriod(rest[n]) {
+			fracLen, _ := numLen(rest[n+1:], 0, len(rest))
+			if fracLen == 0 {
+				return Duration{}, parseErr(rest[n:], "missing fraction")
+			}
+			frac = rest[n+1 : n+1+fracLen]
+			n += 1 + fracLen
+		}
+		if n == len(rest) {
+			return Duration{}, parseErr(rest, "missing designator")
+		}
+		designator := rest[n]
+		idx := strings.IndexByte(designators, designator)
+		if idx < 0 {
+			return Duration{}, parseErr(rest[n:], "unexpected designator")
+		}
+		if frac != "" && !(inTime && designator == 'S') {
+			return Duration{}, parseErr(rest, "fraction is only allowed for seconds")
+		}
+		designators = designators[idx+1:]
+
+		switch {
+		case !inTime && designator == 'Y':
+			d.Years = num
+		case !inTime && designator == 'M':
+			d.Months = num
+		case designator == 'W':
+			d.Weeks = num
+		case designator == 'D':
+			d.Days = num
+		case designator == 'H':
+			if !fitsDuration(num, time.Hour) {
+				return Duration{}, parseErr(rest, "number out of range")
+			}
+			d.Hours = num
+		case inTime && designator == 'M':
+			if !fitsDuration(num, time.Minute) {
+				return Duration{}, parseErr(rest, "number out of range")
+			}
+			d.Minutes = num
+		case designator == 'S':
+			if len(frac) > 9 {
+				frac = frac[:9]
+			}
+			nsec, _ := strconv.Atoi(frac + strings.Repeat("0", 9-len(frac)))
+			if !fitsDuration(num, time.Second) || time.Duration(num)*time.Second > math.MaxInt64-time.Duration(nsec) {
+				return Duration{}, parseErr(rest, "number out of range")
+			}
+			d.Seconds = time.Duration(num)*time.Second + time.Duration(nsec)
+		}
+		found = true
+		rest = rest[n+1:]
+	}
+	if !found {
+		return Duration{}, parseErr(value, "no components")
+	}
+	return d, nil
+}
+
+// String returns d in ISO 8601. Zero components are omitted. The zero Duration is PT0S.
+func (d Duration) String() string {
+	var b strings.Builder
+	if d.Negative {
+		b.WriteByte('-')
+	}
+	b.WriteByte('P')
+	prefixLen := b.Len()
+	for _, c := range []struct {
+		n          int
+		designator byte
+	}{{d.Years, 'Y'}, {d.Months, 'M'}, {d.Weeks, 'W'}, {d.Days, 'D'}} {
+		if c.n != 0 {
+			b.WriteString(strconv.Itoa(c.n))
+			b.WriteByte(c.designator)
+		}
+	}
+	if d.Hours == 0 && d.Minutes == 0 && d.Seconds == 0 {
+		if b.Len() == prefixLen {
+			b.WriteString("T0S")
+		}
+		return b.String()
+	}
+	b.WriteByte('T')
+	if d.Hours != 0 {
+		b.WriteString(strconv.Itoa(d.Hours) + "H")
+	}
+	if d.Minutes != 0 {
+		b.WriteString(strconv.Itoa(d.Minutes) + "M")
+	}
+	if d.Seconds != 0 {
+		// Formatted from the absolute value so that the fraction of negative seconds is not inverted.
+		// uint64 conversion keeps -math.MinInt64 right.
+		abs := uint64(d.Seconds)
+		if d.Seconds < 0 {
+			b.WriteByte('-')
+			abs = uint64(-d.Seconds)
+		}
+		b.WriteString(strconv.FormatUint(abs/uint64(time.Second), 10))
+		if nsec := abs % uint64(time.Second); nsec != 0 {
+			b.WriteByte('.')
+			b.WriteString(strings.TrimRight(strconv.FormatUint(uint64(time.Second)+nsec, 10)[1:], "0"))
+		}
+		b.WriteByte('S')
+	}
+	return b.String()
+}
+
+// fitsDuration reports whether n of unit fits in time.Duration.
+func fitsDuration(n int, unit time.Duration) bool {
+	return int64(n) <= math.MaxInt64/int64(unit) && int64(n) >= math.MinInt64/int64(unit)
+}
+
+// addDuration adds n of unit to t. n is saturated to what fits in time.Duration,
+// beyond which t would be out of the range time.Time can practically represent.
+func addDuration(t time.Time, n int, unit time.Duration) time.Time {
+	switch {
+	case int64(n) > math.MaxInt64/int64(unit):
+		return t.Add(math.MaxInt64 / unit * unit)
+	case int64(n) < math.MinInt64/int64(unit):
+		return t.Add(math.MinInt64 / unit * unit)
+	}
+	return t.Add(time.Duration(n) * unit)
+}
+
+// AddTo returns t plus d. Years and months are added by the calendar of the location of t
+// with the day clamped to the end of the month, e.g. 2022-01-31 plus P1M is 2022-02-28.
+// Then weeks and days are added as time.Time.AddDate does, and finally hours, minutes and seconds as elapsed time,
+// each of which is added separately so that their sum does not overflow.
+// If d is negative, they are subtracted in the reverse order.
+func (d Duration) AddTo(t time.Time) time.Time {
+	months := 12*d.Years + d.Months
+	days := 7*d.Weeks + d.Days
+	if d.Negative {
+		t = addDuration(addDuration(t, -d.Hours, time.Hour), -d.Minutes, time.Minute)
+		if d.Seconds == math.MinInt64 {
+			// -d.Seconds overflows.
+			t = t.Add(math.MaxInt64).Add(time.Nanosecond)
+		} else {
+			t = t.Add(-d.Seconds)
+		}
+		return addMonthsClamped(t.AddDate(0, 0, -days), -months)
+	}
+	t = addMonthsClamped(t, months).AddDate(0, 0, days)
+	return addDuration(addDuration(t, d.Hours, time.Hour), d.Minutes, time.Minute).Add(d.Seconds)
+}
+
+// Negate returns d with its sign inverted.
+func (d Duration) Negate() Duration {
+	d.Negative = !d.Negative
+	return d
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
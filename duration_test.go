@@ -0,0 +1,89 @@
+package flextime_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuration(t *testing.T) {
+	for _, testCase := range []struct {
+		input     string
+		expected  flextime.Duration
+		formatted string
+	}{
+		{"P1Y2M3DT4H5M6.5S", flextime.Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6500 * time.Millisecond}, ""},
+		{"P2W", flextime.Duration{Weeks: 2}, ""},
+		{"PT1H", flextime.Duration{Hours: 1}, ""},
+		{"PT0,000000001S", flextime.Duration{Seconds: 1}, "PT0.000000001S"},
+		{"P1M", flextime.Duration{Months: 1}, ""},
+		{"PT1M", flextime.Duration{Minutes: 1}, ""},
+		{"-P1D", flextime.Duration{Negative: true, Days: 1}, ""},
+		{"+P1D", flextime.Duration{Days: 1}, "P1D"},
+		{"P0D", flextime.Duration{}, "PT0S"},
+		{"PT9223372036.854775807S", flextime.Duration{Seconds: math.MaxInt64}, ""},
+	} {
+		parsed, err := flextime.ParseDuration(testCase.input)
+		require.NoError(t, err, testCase.input)
+		assert.Equal(t, testCase.expected, parsed, testCase.input)
+		formatted := testCase.formatted
+		if formatted == "" {
+			formatted = testCase.input
+		}
+		assert.Equal(t, formatted, parsed.String(), testCase.input)
+	}
+
+	for _, invalid := range []string{"", "P", "PT", "1D", "P1", "PD", "P1D2Y", "P1H", "PT1D", "P1.5D", "PT1.S", "P1DT1H1H", "P1DTT1H",
+		"PT0.5H", "P0.5D", "PT9999999999999S", "PT9223372036.854775808S", "PT2562048H", "PT153722868M",
+	} {
+		_, err := flextime.ParseDuration(invalid)
+		var parseErr *time.ParseError
+		assert.ErrorAs(t, err, &parseErr, invalid)
+	}
+
+	// negative seconds are formatted from the absolute value.
+	assert.Equal(t, "PT-1.5S", flextime.Duration{Seconds: -1500 * time.Millisecond}.String())
+	assert.Equal(t, "PT-9223372036.854775808S", flextime.Duration{Seconds: math.MinInt64}.String())
+}
+
+func TestDurationAddTo(t *testing.T) {
+	d, err := flextime.ParseDuration("P1M1DT1H")
+	require.NoError(t, err)
+	// calendar components follow the calendar, not a fixed length. The day is clamped to the end of February.
+	assert.Equal(t, time.Date(2022, 3, 1, 1, 0, 0, 0, time.UTC), d.AddTo(time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2022, 1, 28, 0, 0, 0, 0, time.UTC), d.Negate().AddTo(time.Date(2022, 3, 1, 1, 0, 0, 0, time.UTC)))
+
+	// across a DST transition, a day is not 24 hours.
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	d, err = flextime.ParseDuration("P1D")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 3, 13, 12, 0, 0, 0, ny), d.AddTo(time.Date(2022, 3, 12, 12, 0, 0, 0, ny)))
+
+	// hours, minutes and seconds are added separately, so their sum does not overflow.
+	base := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	d = flextime.Duration{Hours: 2562047, Minutes: 153722867, Seconds: math.MaxInt64}
+	expected := base.Add(2562047 * time.Hour).Add(153722867 * time.Minute).Add(math.MaxInt64)
+	assert.Equal(t, expected, d.AddTo(base))
+	assert.Equal(t, base, d.Negate().AddTo(expected))
+	assert.True(t, flextime.Duration{Hours: 3000000}.AddTo(base).After(base))
+}
+
+func TestDurationJSON(t *testing.T) {
+	type payload struct {
+		D flextime.Duration `json:"d"`
+	}
+	var p payload
+	require.NoError(t, json.Unmarshal([]byte(`{"d":"P1DT2H"}`), &p))
+	assert.Equal(t, flextime.Duration{Days: 1, Hours: 2}, p.D)
+	bin, err := json.Marshal(p)
+	require.NoError(t, err)
+	assert.Equal(t, `{"d":"P1DT2H"}`, string(bin))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"d":"1 day"}`), &p))
+}
@@ -0,0 +1,38 @@
+package flextime
+
+import "time"
+
+// WithEndOfDay makes HH accept 24, as 24:00 or 24:00:00 of ISO 8601, meaning 00:00 of the next day.
+// Minutes, seconds and fractional seconds after 24 must be zero.
+// Format is not affected; see WithEndOfDayFormat.
+func WithEndOfDay() Option {
+	return optionFunc(func(o *options) {
+		o.endOfDay = true
+	})
+}
+
+// WithEndOfDayFormat makes Format write 00:00 as 24:00 of the previous day if the layout has HH.
+// Formatted values are parsed back to the same time only with WithEndOfDay.
+func WithEndOfDayFormat() Option {
+	return optionFunc(func(o *options) {
+		o.endOfDayFormat = true
+	})
+}
+
+// endOfDayHour is the hour ending a day.
+const endOfDayHour = "24"
+
+func isMidnight(t time.Time) bool {
+	h, m, s := t.Clock()
+	return h == 0 && m == 0 && s == 0 && t.Nanosecond() == 0
+}
+
+// hasHourChunk reports whether chunks has the 24-hour clock token.
+func hasHourChunk(chunks []layoutChunk) bool {
+	for _, c := range chunks {
+		if c.kind == stdChunk && c.value == "15" {
+			return true
+		}
+	}
+	return false
+}
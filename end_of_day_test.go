@@ -0,0 +1,62 @@
+package flextime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndOfDay(t *testing.T) {
+	layouts, err := flextime.NewLayoutSet(`YYYY-MM-DDTHH:mm[:ss][.SSS][Z]`)
+	require.NoError(t, err)
+	f := flextime.NewFlextime(layouts, flextime.WithEndOfDay())
+
+	for _, testCase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"2022-01-02T24:00", time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"2022-12-31T24:00:00", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2022-02-28T24:00:00.000+09:00", time.Date(2022, 3, 1, 0, 0, 0, 0, jst)},
+		{"2022-01-02T15:04:05", time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"2022-01-02T00:00", time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)},
+	} {
+		parsed, err := f.Parse(testCase.input)
+		require.NoError(t, err, "input = %s", testCase.input)
+		assert.True(t, testCase.expected.Equal(parsed), "input = %s, parsed = %s", testCase.input, parsed)
+	}
+
+	for _, input := range []string{
+		"2022-01-02T24:01",
+		"2022-01-02T24:00:01",
+		"2022-01-02T24:00:00.001",
+		"2022-01-02T25:00",
+	} {
+		_, err := f.Parse(input)
+		assert.Error(t, err, "input = %s", input)
+	}
+
+	_, err = flextime.NewFlextime(layouts).Parse("2022-01-02T24:00")
+	assert.Error(t, err)
+
+	// WithEndOfDay alone keeps Format round-tripping to the same date.
+	assert.Equal(t, "2022-01-03T00:00:00.000Z", f.Format(time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestEndOfDayFormat(t *testing.T) {
+	layouts, err := flextime.NewLayoutSet(`YYYY-MM-DDTHH:mm[:ss][.SSS][Z]`)
+	require.NoError(t, err)
+	f := flextime.NewFlextime(layouts, flextime.WithEndOfDay(), flextime.WithEndOfDayFormat())
+
+	midnight := time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+	formatted := f.Format(midnight)
+	assert.Equal(t, "2022-01-02T24:00:00.000Z", formatted)
+	parsed, err := f.Parse(formatted)
+	require.NoError(t, err)
+	assert.True(t, midnight.Equal(parsed))
+
+	assert.Equal(t, "2022-01-03T00:00:00.001Z", f.Format(time.Date(2022, 1, 3, 0, 0, 0, 1000000, time.UTC)))
+}
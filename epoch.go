@@ -0,0 +1,170 @@
+package flextime
+
+import (
+	"math"
+	"time"
+)
+
+// Converters between time.Time and numeric timestamps of spreadsheets and platforms.
+// Ones which take int64 can be passed to NewCombined as numParser.
+// Ones which take float64 can be passed to WithFloatParser so that fractions are kept.
+
+var (
+	excelEpoch             = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+	excelLeapBugEnd        = time.Date(1900, time.March, 1, 0, 0, 0, 0, time.UTC)
+	julianDayEpoch         = time.Date(-4713, time.November, 24, 12, 0, 0, 0, time.UTC)
+	modifiedJulianDayEpoch = time.Date(1858, time.November, 17, 0, 0, 0, 0, time.UTC)
+	appleEpoch             = time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC)
+	gpsEpoch               = time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC)
+)
+
+const (
+	// filetimeUnixOffset is 1970-01-01 in 100-nanosecond intervals since 1601-01-01.
+	filetimeUnixOffset = 116444736000000000
+	// dotNetTicksUnixOffset is 1970-01-01 in 100-nanosecond intervals since 0001-01-01.
+	dotNetTicksUnixOffset = 621355968000000000
+)
+
+// FromExcelSerial converts a serial date of Excel and OLE Automation, fractional days since 1899-12-30, into time in UTC.
+// It is rounded to milliseconds, which is the precision of Excel.
+//
+// Excel thinks 1900 is a leap year. To be compatible with it, serials less than 60 are counted from 1899-12-31,
+// so that 1 is 1900-01-01. 60, 1900-02-29 which does not exist, is taken as 1900-02-28.
+func FromExcelSerial(serial float64) time.Time {
+	if serial < 60 {
+		serial++
+	}
+	return fromDays(excelEpoch, serial, time.Millisecond)
+}
+
+// ToExcelSerial is the inverse of FromExcelSerial.
+func ToExcelSerial(t time.Time) float64 {
+	serial := toDays(excelEpoch, t)
+	if t.Before(excelLeapBugEnd) {
+		serial--
+	}
+	return serial
+}
+
+// FromFiletime converts FILETIME of Windows, 100-nanosecond intervals since 1601-01-01 UTC, into time.
+func FromFiletime(ft int64) time.Time {
+	return fromUnix100ns(ft - filetimeUnixOffset)
+}
+
+// ToFiletime is the inverse of FromFiletime.
+func ToFiletime(t time.Time) int64 {
+	return toUnix100ns(t) + filetimeUnixOffset
+}
+
+// FromDotNetTicks converts DateTime.Ticks of .NET, 100-nanosecond intervals since 0001-01-01, into time in UTC.
+func FromDotNetTicks(ticks int64) time.Time {
+	return fromUnix100ns(ticks - dotNetTicksUnixOffset)
+}
+
+// ToDotNetTicks is the inverse of FromDotNetTicks.
+func ToDotNetTicks(t time.Time) int64 {
+	return toUnix100ns(t) + dotNetTicksUnixOffset
+}
+
+// FromAppleAbsolute converts absolute time of Apple platforms (CFAbsoluteTime),
+// seconds since 2001-01-01 UTC, into time. It is rounded to microseconds.
+func FromAppleAbsolute(sec float64) time.Time {
+	whole := math.Floor(sec)
+	nsec := time.Duration(math.Round((sec - whole) * 1e9)).Round(time.Microsecond)
+	return time.Unix(appleEpoch.Unix()+int64(whole), int64(nsec)).UTC()
+}
+
+// ToAppleAbsolute is the inverse of FromAppleAbsolute.
+func ToAppleAbsolute(t time.Time) float64 {
+	return float64(t.Unix()-appleEpoch.Unix()) + float64(t.Nanosecond())/1e9
+}
+
+// gpsLeapSeconds is leap seconds inserted since the GPS epoch, as the unix time right after each of them.
+var gpsLeapSeconds = []int64{
+	time.Date(1981, time.July, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	time.Date(1982, time.July, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	time.Date(1983, time.July, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	time.Date(1985, time.July, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	time.Date(1988, time.January, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	time.Date(1991, time.January, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	time.Date(1992, time.July, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	time.Date(1993, time.July, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	time.Date(1994, time.July, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	time.Date(1996, time.January, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	time.Date(1997, time.July, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	time.Date(1999, time.January, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	time.Date(2015, time.July, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC).Unix(),
+}
+
+// FromGPS converts GPS time, seconds since 1980-01-06 UTC without leap seconds, into time in UTC.
+// Leap seconds announced after 2017 are not known to it.
+// A leap second itself is taken as the first second of the next day.
+func FromGPS(sec int64) time.Time {
+	unix := gpsEpoch.Unix() + sec
+	for _, leap := range gpsLeapSeconds {
+		if unix-1 < leap {
+			break
+		}
+		unix--
+	}
+	return time.Unix(unix, 0).UTC()
+}
+
+// ToGPS is the inverse of FromGPS. Fractions of seconds are truncated.
+func ToGPS(t time.Time) int64 {
+	unix := t.Unix()
+	sec := unix - gpsEpoch.Unix()
+	for _, leap := range gpsLeapSeconds {
+		if unix < leap {
+			break
+		}
+		sec++
+	}
+	return sec
+}
+
+// FromJulianDay converts Julian Day, fractional days since -4713-11-24 12:00 UTC of the proleptic Gregorian calendar,
+// into time in UTC. It is rounded to milliseconds since float64 can not keep more for recent dates.
+func FromJulianDay(jd float64) time.Time {
+	return fromDays(julianDayEpoch, jd, time.Millisecond)
+}
+
+// ToJulianDay is the inverse of FromJulianDay.
+func ToJulianDay(t time.Time) float64 {
+	return toDays(julianDayEpoch, t)
+}
+
+// FromModifiedJulianDay converts Modified Julian Day, fractional days since 1858-11-17 UTC, into time in UTC.
+// It is rounded to milliseconds.
+func FromModifiedJulianDay(mjd float64) time.Time {
+	return fromDays(modifiedJulianDayEpoch, mjd, time.Millisecond)
+}
+
+// ToModifiedJulianDay is the inverse of FromModifiedJulianDay.
+func ToModifiedJulianDay(t time.Time) float64 {
+	return toDays(modifiedJulianDayEpoch, t)
+}
+
+func fromDays(epoch time.Time, days float64, precision time.Duration) time.Time {
+	whole := math.Floor(days)
+	frac := time.Duration(math.Round((days - whole) * float64(24*time.Hour))).Round(precision)
+	return epoch.AddDate(0, 0, int(whole)).Add(frac)
+}
+
+func toDays(epoch time.Time, t time.Time) float64 {
+	sec := t.Unix() - epoch.Unix()
+	return float64(sec)/(24*60*60) + float64(t.Nanosecond()-epoch.Nanosecond())/float64(24*time.Hour)
+}
+
+func fromUnix100ns(v int64) time.Time {
+	return time.Unix(v/1e7, v%1e7*100).UTC()
+}
+
+func toUnix100ns(t time.Time) int64 {
+	return t.Unix()*1e7 + int64(t.Nanosecond())/100
+}
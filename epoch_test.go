@@ -0,0 +1,84 @@
+package flextime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEpoch(t *testing.T) {
+	tm := time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	for _, testCase := range []struct {
+		name     string
+		from     func() time.Time
+		to       func() float64
+		expected float64
+	}{
+		{"excel", func() time.Time { return flextime.FromExcelSerial(44563.62783564815) }, func() float64 { return flextime.ToExcelSerial(tm) }, 44563.62783564815},
+		{"filetime", func() time.Time { return flextime.FromFiletime(132856094450000000) }, func() float64 { return float64(flextime.ToFiletime(tm)) }, 132856094450000000},
+		{"ticks", func() time.Time { return flextime.FromDotNetTicks(637767326450000000) }, func() float64 { return float64(flextime.ToDotNetTicks(tm)) }, 637767326450000000},
+		{"apple", func() time.Time { return flextime.FromAppleAbsolute(662828645) }, func() float64 { return flextime.ToAppleAbsolute(tm) }, 662828645},
+		{"gps", func() time.Time { return flextime.FromGPS(1325171063) }, func() float64 { return float64(flextime.ToGPS(tm)) }, 1325171063},
+		{"jd", func() time.Time { return flextime.FromJulianDay(2459582.127835648) }, func() float64 { return flextime.ToJulianDay(tm) }, 2459582.127835648},
+		{"mjd", func() time.Time { return flextime.FromModifiedJulianDay(59581.62783564815) }, func() float64 { return flextime.ToModifiedJulianDay(tm) }, 59581.62783564815},
+	} {
+		from := testCase.from()
+		assert.True(t, tm.Equal(from), "%s: expected = %s, actual = %s", testCase.name, tm, from)
+		assert.InDelta(t, testCase.expected, testCase.to(), 1e-6, testCase.name)
+	}
+}
+
+func TestEpochExcelLeapYearBug(t *testing.T) {
+	for _, testCase := range []struct {
+		serial   float64
+		expected time.Time
+	}{
+		{1, time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{59, time.Date(1900, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{59.5, time.Date(1900, 2, 28, 12, 0, 0, 0, time.UTC)},
+		{60, time.Date(1900, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{61, time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		assert.Equal(t, testCase.expected, flextime.FromExcelSerial(testCase.serial), "%v", testCase.serial)
+	}
+	assert.Equal(t, 59., flextime.ToExcelSerial(time.Date(1900, 2, 28, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 61., flextime.ToExcelSerial(time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestEpochGPSLeapSeconds(t *testing.T) {
+	// GPS was 17 seconds ahead of UTC in 2016 and 18 seconds after the leap second at the end of it.
+	lastOf2016 := time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC)
+	gps := flextime.ToGPS(lastOf2016)
+	assert.Equal(t, lastOf2016.Unix()-315964800+17, gps)
+	assert.Equal(t, lastOf2016, flextime.FromGPS(gps))
+	// 2016-12-31T23:59:60Z
+	assert.Equal(t, lastOf2016.Add(time.Second), flextime.FromGPS(gps+1))
+	assert.Equal(t, lastOf2016.Add(time.Second), flextime.FromGPS(gps+2))
+	assert.Equal(t, gps+2, flextime.ToGPS(lastOf2016.Add(time.Second)))
+}
+
+func TestEpochCombined(t *testing.T) {
+	tm := time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	excel := flextime.NewCombined(nil, nil, flextime.WithFloatParser(flextime.FromExcelSerial))
+	for _, v := range []any{44563.62783564815, []byte(`44563.62783564815`)} {
+		parsed, err := excel.Parse(v)
+		require.NoError(t, err)
+		assert.True(t, tm.Equal(parsed), "actual = %s", parsed)
+	}
+	parsed, err := excel.Parse(44563)
+	require.NoError(t, err)
+	assert.True(t, time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC).Equal(parsed), "actual = %s", parsed)
+
+	// FILETIME exceeds 2^53, which float64 can not keep.
+	filetime := flextime.NewCombined(nil, flextime.FromFiletime)
+	for _, v := range []any{int64(132856094450000001), []byte(`132856094450000001`)} {
+		parsed, err := filetime.Parse(v)
+		require.NoError(t, err)
+		assert.True(t, tm.Add(100).Equal(parsed), "actual = %s", parsed)
+	}
+}
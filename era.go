@@ -0,0 +1,94 @@
+package flextime
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Era is an era of a calendar, e.g. Reiwa of the Japanese calendar.
+type Era struct {
+	// Name is the name of the era, e.g. 令和.
+	Name string
+	// Abbr is the abbreviated name of the era, e.g. R.
+	Abbr string
+	// Year, Month and Day are the first date of the era in the Gregorian calendar.
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+func (e Era) start() time.Time {
+	return time.Date(e.Year, e.Month, e.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// EraTable is a list of eras, oldest first.
+type EraTable []Era
+
+// JapaneseEras is eras of the Japanese calendar since the adoption of the Gregorian calendar.
+var JapaneseEras = EraTable{
+	{Name: "明治", Abbr: "M", Year: 1868, Month: time.September, Day: 8},
+	{Name: "大正", Abbr: "T", Year: 1912, Month: time.July, Day: 30},
+	{Name: "昭和", Abbr: "S", Year: 1926, Month: time.December, Day: 25},
+	{Name: "平成", Abbr: "H", Year: 1989, Month: time.January, Day: 8},
+	{Name: "令和", Abbr: "R", Year: 2019, Month: time.May, Day: 1},
+}
+
+// Add returns a new EraTable which has e in addition to eras of t.
+func (t EraTable) Add(e Era) EraTable {
+	added := append(append(EraTable{}, t...), e)
+	sort.SliceStable(added, func(i, j int) bool {
+		return added[i].start().Before(added[j].start())
+	})
+	return added
+}
+
+// Find returns the era which tm belongs to. The date of tm is taken in its location.
+func (t EraTable) Find(tm time.Time) (Era, bool) {
+	date := time.Date(tm.Year(), tm.Month(), tm.Day(), 0, 0, 0, 0, time.UTC)
+	for i := len(t) - 1; i >= 0; i-- {
+		if !date.Before(t[i].start()) {
+			return t[i], true
+		}
+	}
+	return Era{}, false
+}
+
+// contains reports whether the date of tm, taken in its location, is in e, before the next era of t begins.
+func (t EraTable) contains(e Era, tm time.Time) bool {
+	date := time.Date(tm.Year(), tm.Month(), tm.Day(), 0, 0, 0, 0, time.UTC)
+	if date.Before(e.start()) {
+		return false
+	}
+	for _, other := range t {
+		if other.start().After(e.start()) && !date.Before(other.start()) {
+			return false
+		}
+	}
+	return true
+}
+
+// lookup finds an era whose name (or abbreviated name if abbr is true) is at the head of value.
+// The longest name wins.
+func (t EraTable) lookup(value string, abbr, fold bool) (era Era, length int, ok bool) {
+	for _, e := range t {
+		name := e.Name
+		if abbr {
+			name = e.Abbr
+		}
+		if name == "" || len(name) <= length {
+			continue
+		}
+		if strings.HasPrefix(value, name) || (fold && hasPrefixFold(value, name)) {
+			era, length, ok = e, len(name), true
+		}
+	}
+	return era, length, ok
+}
+
+// WithEras replaces the era table which era tokens refer to. The default is JapaneseEras.
+func WithEras(eras EraTable) Option {
+	return optionFunc(func(o *options) {
+		o.eras = eras
+	})
+}
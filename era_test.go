@@ -0,0 +1,105 @@
+package flextime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJapaneseEra(t *testing.T) {
+	cases := []struct {
+		layouts  *flextime.LayoutSet
+		input    string
+		expected time.Time
+		format   string
+	}{
+		{
+			layouts:  flextime.JapaneseDate,
+			input:    "2022年1月2日 15時04分",
+			expected: time.Date(2022, 1, 2, 15, 4, 0, 0, jst),
+			format:   "2022年1月2日 15時04分00秒",
+		},
+		{
+			layouts:  flextime.JapaneseEraDate,
+			input:    "令和4年1月2日",
+			expected: time.Date(2022, 1, 2, 0, 0, 0, 0, jst),
+			format:   "令和4年1月2日 00時00分00秒",
+		},
+		{
+			layouts:  flextime.JapaneseEraDate,
+			input:    "令和元年5月1日 9時30分15秒",
+			expected: time.Date(2019, 5, 1, 9, 30, 15, 0, jst),
+			format:   "令和1年5月1日 09時30分15秒",
+		},
+		{
+			layouts:  flextime.JapaneseEraDate,
+			input:    "平成31年4月30日",
+			expected: time.Date(2019, 4, 30, 0, 0, 0, 0, jst),
+			format:   "平成31年4月30日 00時00分00秒",
+		},
+		{
+			layouts:  flextime.JapaneseEraAbbrDate,
+			input:    "R4.01.02",
+			expected: time.Date(2022, 1, 2, 0, 0, 0, 0, jst),
+			format:   "R4.01.02",
+		},
+		{
+			layouts:  flextime.JapaneseEraAbbrDate,
+			input:    "S64.01.07",
+			expected: time.Date(1989, 1, 7, 0, 0, 0, 0, jst),
+			format:   "S64.01.07",
+		},
+	}
+
+	for _, testCase := range cases {
+		p := flextime.NewFlextime(testCase.layouts)
+		parsed, err := p.ParseInLocation(testCase.input, jst)
+		require.NoError(t, err, testCase.input)
+		require.True(t, testCase.expected.Equal(parsed), "expected = %s, actual = %s", testCase.expected, parsed)
+		require.Equal(t, testCase.format, p.Format(parsed))
+	}
+}
+
+func TestJapaneseEraCustomTable(t *testing.T) {
+	eras := flextime.JapaneseEras.Add(flextime.Era{Name: "未来", Abbr: "X", Year: 2100, Month: time.January, Day: 1})
+	p := flextime.NewFlextime(flextime.JapaneseEraAbbrDate, flextime.WithEras(eras))
+
+	parsed, err := p.ParseInLocation("X2.03.04", jst)
+	require.NoError(t, err)
+	require.True(t, time.Date(2101, 3, 4, 0, 0, 0, 0, jst).Equal(parsed))
+	require.Equal(t, "R81.12.31", p.Format(time.Date(2099, 12, 31, 0, 0, 0, 0, jst)))
+
+	_, err = flextime.NewFlextime(flextime.JapaneseEraAbbrDate).Parse("X2.03.04")
+	require.Error(t, err)
+}
+
+func TestEraYearWithoutEra(t *testing.T) {
+	l, err := flextime.NewSingleLayout(`E年M月D日`)
+	require.NoError(t, err)
+	_, err = flextime.NewFlextime(l).Parse("4年1月2日")
+	var parseErr *time.ParseError
+	require.ErrorAs(t, err, &parseErr)
+}
+
+func TestJapaneseEraSpan(t *testing.T) {
+	p := flextime.NewFlextime(flextime.JapaneseEraDate.AddLayout(flextime.JapaneseEraAbbrDate))
+	for _, input := range []string{
+		"令和1年4月30日",
+		"平成31年5月1日",
+		"平成32年1月2日",
+		"H32.01.02",
+		"昭和64年1月8日",
+		"明治1年9月7日",
+	} {
+		_, err := p.ParseInLocation(input, jst)
+		var parseErr *time.ParseError
+		require.ErrorAs(t, err, &parseErr, input)
+	}
+
+	for _, input := range []string{"令和元年5月1日", "平成31年4月30日", "平成元年1月8日", "S64.01.07"} {
+		_, err := p.ParseInLocation(input, jst)
+		require.NoError(t, err, input)
+	}
+}
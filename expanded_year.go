@@ -0,0 +1,60 @@
+package flextime
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Expanded years are written as runs of Y as long as their digits, e.g. YYYYYY for +012022.
+const (
+	minExpandedYearDigits = 5
+	maxExpandedYearDigits = 9
+)
+
+// isExpandedYearToken reports whether name is an expanded year token, a run of 5 through 9 Y.
+func isExpandedYearToken(name string) bool {
+	return len(name) >= minExpandedYearDigits && len(name) <= maxExpandedYearDigits &&
+		strings.Trim(name, "Y") == ""
+}
+
+// gregorianCycle is the number of years after which the Gregorian calendar repeats itself, weekdays included.
+const gregorianCycle = 400
+
+// parseExpandedYear parses a signed year of digits digits, e.g. +012022 or -000044, the expanded representation of ISO 8601.
+//
+// time.Parse does not accept years out of 0 through 9999.
+// The year is passed to it as a year of the same position in the 400-year cycle,
+// and moved back after parsed, which keeps leap days and weekdays.
+func parseExpandedYear(digits int) func(s *scanner) bool {
+	return func(s *scanner) bool {
+		if len(s.rest) == 0 || (s.rest[0] != '+' && s.rest[0] != '-') {
+			return false
+		}
+		n, ok := numLen(s.rest[1:], digits, digits)
+		if !ok {
+			return false
+		}
+		year := 0
+		for _, c := range s.rest[1 : 1+n] {
+			year = year*10 + int(c-'0')
+		}
+		if s.rest[0] == '-' {
+			year = -year
+		}
+		proxy := 2000 + mod(year, gregorianCycle)
+		s.fields.yearShift = year - proxy
+		s.emit("2006", fmt.Sprintf("%04d", proxy), 1+n)
+		return true
+	}
+}
+
+func formatExpandedYear(digits int) func(o *options, t time.Time) string {
+	return func(o *options, t time.Time) string {
+		year, sign := t.Year(), "+"
+		if year < 0 {
+			year, sign = -year, "-"
+		}
+		return fmt.Sprintf("%s%0*d", sign, digits, year)
+	}
+}
@@ -0,0 +1,79 @@
+package flextime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandedYear(t *testing.T) {
+	layouts, err := flextime.NewLayoutSet(`YYYYYY-MM-DD[THH:mm:ssZ]`)
+	require.NoError(t, err)
+	f := flextime.NewFlextime(layouts)
+
+	for _, testCase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"+012022-01-01", time.Date(12022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"-000044-03-15", time.Date(-44, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"+002022-01-02T15:04:05+09:00", time.Date(2022, 1, 2, 15, 4, 5, 0, jst)},
+		{"+000000-02-29", time.Date(0, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"-000400-02-29", time.Date(-400, 2, 29, 0, 0, 0, 0, time.UTC)},
+	} {
+		parsed, err := f.Parse(testCase.input)
+		require.NoError(t, err, "input = %s", testCase.input)
+		assert.True(t, testCase.expected.Equal(parsed), "input = %s, parsed = %s", testCase.input, parsed)
+		assert.Equal(t, testCase.expected.Weekday(), parsed.Weekday())
+	}
+
+	for _, input := range []string{
+		"2022-01-01",
+		"012022-01-01",
+		"+12022-01-01",
+		// 1900 is not a leap year.
+		"+001900-02-29",
+		"-000100-02-29",
+	} {
+		_, err := f.Parse(input)
+		assert.Error(t, err, "input = %s", input)
+	}
+
+	assert.Equal(t, "-000044-03-15T00:00:00Z", f.Format(time.Date(-44, 3, 15, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "+012022-01-01T00:00:00Z", f.Format(time.Date(12022, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	five, err := flextime.NewLayoutSet(`YYYYY-MM-DD`)
+	require.NoError(t, err)
+	parsed, err := flextime.NewFlextime(five).Parse("+10000-01-01")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC), parsed)
+
+	// the width is the number of Y, 5 through 9.
+	for _, testCase := range []struct {
+		layout string
+		input  string
+		year   int
+	}{
+		{`YYYYYYY-MM-DD`, "+0012022-01-01", 12022},
+		{`YYYYYYYYY-MM-DD`, "-000000044-03-15", -44},
+		{`YYYYYYYYY-MM-DD`, "+123456789-01-01", 123456789},
+	} {
+		layouts, err := flextime.NewLayoutSet(testCase.layout)
+		require.NoError(t, err, testCase.layout)
+		f := flextime.NewFlextime(layouts)
+		parsed, err := f.Parse(testCase.input)
+		require.NoError(t, err, testCase.input)
+		assert.Equal(t, testCase.year, parsed.Year(), testCase.input)
+		assert.Equal(t, testCase.input, f.Format(parsed), testCase.input)
+	}
+	seven, err := flextime.NewLayoutSet(`YYYYYYY-MM-DD`)
+	require.NoError(t, err)
+	_, err = flextime.NewFlextime(seven).Parse("+012022-01-01")
+	assert.Error(t, err)
+
+	_, err = flextime.NewLayoutSet(`YYYYYYYYYY-MM-DD`)
+	assert.Error(t, err)
+}
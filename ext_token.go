@@ -0,0 +1,135 @@
+package flextime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// extTokenHandler parses and formats an extension token, a token time.Parse does not know.
+type extTokenHandler struct {
+	// parse consumes the head of s.rest and emits its replacement.
+	// It reports false if the value does not match to the token.
+	parse  func(s *scanner) bool
+	format func(o *options, t time.Time) string
+}
+
+var extTokens = map[string]extTokenHandler{
+	"Do":    {parse: parseOrdinalDay, format: formatOrdinalDay},
+	"GGGG":  {parse: parseEra(false), format: formatEra(false)},
+	"GGGGG": {parse: parseEra(true), format: formatEra(true)},
+	"E":     {parse: parseEraYear(1), format: formatEraYear("%d")},
+	"EE":    {parse: parseEraYear(2), format: formatEraYear("%02d")},
+	"EEEE":  {parse: parseCalendarYear, format: formatCalendarYear},
+	"z":     {parse: parseZoneAbbr, format: formatZoneAbbr},
+	"zzzz":  {parse: parseZoneID, format: formatZoneID},
+	"O":     {parse: parsePrefixedOffset, format: formatPrefixedOffset(false)},
+	"OOOO":  {parse: parsePrefixedOffset, format: formatPrefixedOffset(true)},
+	"N":     {parse: parseMilitaryZone, format: formatMilitaryZone},
+
+	// literalBrace has no flextime token. It is written by escapeLiteral for { in literals.
+	literalBrace: {parse: parseLiteralBrace, format: formatLiteralBrace},
+
+	// obs-zone has no flextime token. It is written in layouts of RFC5322Date only.
+	"obs-zone": {parse: parseObsZone, format: formatObsZone},
+	// week dates have no flextime token either. They are written in layouts of ISO8601Extended and ISO8601Basic only.
+	weekDateExtended: {parse: parseWeekDate(true), format: formatWeekDate(true)},
+	weekDateBasic:    {parse: parseWeekDate(false), format: formatWeekDate(false)},
+}
+
+// lookupExtToken returns the handler of the extension token name.
+// Expanded years, runs of Y, are not in extTokens since their width is of the token.
+func lookupExtToken(name string) (extTokenHandler, bool) {
+	if isExpandedYearToken(name) {
+		return extTokenHandler{parse: parseExpandedYear(len(name)), format: formatExpandedYear(len(name))}, true
+	}
+	handler, ok := extTokens[name]
+	return handler, ok
+}
+
+// parseLiteralBrace parses {, a literal escaped by escapeLiteral.
+func parseLiteralBrace(s *scanner) bool {
+	return s.literal(literalBrace)
+}
+
+func formatLiteralBrace(o *options, t time.Time) string {
+	return literalBrace
+}
+
+// parseOrdinalDay parses day of month followed by an ordinal suffix, e.g. 1st or 2nd.
+func parseOrdinalDay(s *scanner) bool {
+	n, ok := numLen(s.rest, 1, 2)
+	if !ok {
+		return false
+	}
+	day, _ := strconv.Atoi(s.rest[:n])
+	suffixLen, ok := s.opts.localeOrDefault().LookupOrdinal(day, s.rest[n:])
+	if !ok {
+		return false
+	}
+	s.emit("2", s.rest[:n], n+suffixLen)
+	return true
+}
+
+func formatOrdinalDay(o *options, t time.Time) string {
+	return strconv.Itoa(t.Day()) + o.localeOrDefault().Ordinal(t.Day())
+}
+
+func parseEra(abbr bool) func(s *scanner) bool {
+	return func(s *scanner) bool {
+		era, n, ok := s.opts.erasOrDefault().lookup(s.rest, abbr, s.opts.caseInsensitive)
+		if !ok {
+			return false
+		}
+		s.fields.era = &era
+		s.emit("", "", n)
+		return true
+	}
+}
+
+func formatEra(abbr bool) func(o *options, t time.Time) string {
+	return func(o *options, t time.Time) string {
+		era, _ := o.erasOrDefault().Find(t)
+		if abbr {
+			return era.Abbr
+		}
+		return era.Name
+	}
+}
+
+// firstEraYear is how the first year of an era is written instead of 1, as in 令和元年.
+const firstEraYear = "元"
+
+func parseEraYear(min int) func(s *scanner) bool {
+	return func(s *scanner) bool {
+		if strings.HasPrefix(s.rest, firstEraYear) {
+			s.fields.eraYear = 1
+			s.emit("", "", len(firstEraYear))
+			return true
+		}
+		n, ok := numLen(s.rest, min, 2)
+		if !ok {
+			return false
+		}
+		year, _ := strconv.Atoi(s.rest[:n])
+		if year == 0 {
+			return false
+		}
+		s.fields.eraYear = year
+		s.emit("", "", n)
+		return true
+	}
+}
+
+// formatEraYear formats year of the era which t belongs to.
+// If t is older than any era, year of the Gregorian calendar is used.
+func formatEraYear(format string) func(o *options, t time.Time) string {
+	return func(o *options, t time.Time) string {
+		year := t.Year()
+		if era, ok := o.erasOrDefault().Find(t); ok {
+			year = year - era.Year + 1
+		}
+		return fmt.Sprintf(format, year)
+	}
+}
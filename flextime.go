@@ -1,30 +1,247 @@
 package flextime
 
 import (
+	"errors"
 	"time"
 )
 
 type Flextime struct {
-	layouts *LayoutSet
+	layouts  *LayoutSet
+	compiled []compiledLayout
+	opts     options
 }
 
-func NewFlextime(layouts *LayoutSet) *Flextime {
+type compiledLayout struct {
+	layout    string
+	chunks    []layoutChunk
+	hasExt    bool
+	hasYear   bool
+	hasDate   bool
+	hasOffset bool
+	// coarsest is the coarsest precision the layout has.
+	coarsest precision
+}
+
+func NewFlextime(layouts *LayoutSet, opts ...Option) *Flextime {
+	return newFlextime(layouts, newOptions(opts))
+}
+
+func newFlextime(layouts *LayoutSet, opts options) *Flextime {
+	compiled := make([]compiledLayout, len(layouts.Layout()))
+	for i, layout := range layouts.Layout() {
+		chunks := splitLayout(layout)
+		compiled[i] = compiledLayout{
+			layout:    layout,
+			chunks:    chunks,
+			hasExt:    hasExtChunk(chunks),
+			hasYear:   hasYearChunk(chunks),
+			hasDate:   hasDateChunk(chunks),
+			hasOffset: hasOffsetChunk(chunks),
+			coarsest:  layoutCoarsest(chunks),
+		}
+	}
 	return &Flextime{
-		layouts: layouts,
+		layouts:  layouts,
+		compiled: compiled,
+		opts:     opts,
 	}
 }
 
 func (f *Flextime) parse(value string, parser func(layout, value string) (time.Time, error)) (time.Time, error) {
+	t, _, err := f.parseMatched(value, parser)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return f.opts.finish(t), nil
+}
+
+// match describes how a value is matched to a layout.
+type match struct {
+	layout *compiledLayout
+	// leapSecond is true if the value had a leap second.
+	leapSecond bool
+}
+
+// parseMatched is same as parse but also returns how value matched.
+func (f *Flextime) parseMatched(
+	value string,
+	parser func(layout, value string) (time.Time, error),
+) (time.Time, match, error) {
+	if !f.opts.rfc9557 {
+		return f.parseUnsuffixed(value, parser, nil)
+	}
+
+	base, tags, msg := splitRFC9557Suffix(value)
+	if msg == "" && len(tags) == 0 {
+		return f.parseUnsuffixed(value, parser, nil)
+	}
+	suffixErr := func(msg string) error {
+		return &time.ParseError{
+			Layout:    f.layouts.Layout()[0],
+			Value:     value,
+			ValueElem: value[len(base):],
+			Message:   ": " + msg,
+		}
+	}
+	if msg != "" {
+		return time.Time{}, match{}, suffixErr(msg)
+	}
+	t, matched, err := f.parseUnsuffixed(base, parser, tags)
+	if err != nil {
+		return time.Time{}, match{}, remapTrimmedError(value, err)
+	}
+	t, msg = f.opts.applySuffix(t, matched.layout, tags)
+	if msg != "" {
+		return time.Time{}, match{}, suffixErr(msg)
+	}
+	return t, matched, nil
+}
+
+// parseUnsuffixed parses value without the RFC 9557 suffix.
+// tags are the suffix, which parseMatched applies. They are used only to check bounds of the final result here.
+func (f *Flextime) parseUnsuffixed(
+	value string,
+	parser func(layout, value string) (time.Time, error),
+	tags []suffixTag,
+) (time.Time, match, error) {
+	if f.opts.trailingComment {
+		if trimmed := trimTrailingComment(value); trimmed != value {
+			t, matched, err := f.parseTrimmed(trimmed, parser, tags)
+			return t, matched, remapTrimmedError(value, err)
+		}
+	}
+	return f.parseTrimmed(value, parser, tags)
+}
+
+func (f *Flextime) parseTrimmed(
+	value string,
+	parser func(layout, value string) (time.Time, error),
+	tags []suffixTag,
+) (time.Time, match, error) {
+	if f.opts.normalize {
+		normalized := normalize(value)
+		t, matched, err := f.parseNormalized(normalized.value, parser, tags)
+		return t, matched, normalized.remapError(err)
+	}
+	return f.parseNormalized(value, parser, tags)
+}
+
+func (f *Flextime) parseNormalized(
+	value string,
+	parser func(layout, value string) (time.Time, error),
+	tags []suffixTag,
+) (time.Time, match, error) {
 	var lastErr error
-	for _, layout := range f.layouts.Layout() {
-		t, err := parser(layout, value)
+	for i, layout := range f.compiled {
+		t, leapSecond, err := f.parseLayout(layout, value, parser, tags)
 		if err != nil {
+			if f.opts.isRejection(err) {
+				return time.Time{}, match{}, err
+			}
 			lastErr = err
 		} else {
-			return t, nil
+			return t, match{layout: &f.compiled[i], leapSecond: leapSecond}, nil
+		}
+	}
+	return time.Time{}, match{}, lastErr
+}
+
+// isRejection reports whether err tells that a value matched a layout but is rejected,
+// so that other layouts should not be tried.
+func (o *options) isRejection(err error) bool {
+	var localErr *LocalTimeError
+	var mismatchErr *DateMismatchError
+	var boundsErr *OutOfBoundsError
+	return errors.As(err, &localErr) || errors.As(err, &mismatchErr) ||
+		(errors.As(err, &boundsErr) && o.bounds != nil && !o.bounds.Continue)
+}
+
+func (f *Flextime) parseLayout(
+	layout compiledLayout,
+	value string,
+	parser func(layout, value string) (time.Time, error),
+	tags []suffixTag,
+) (t time.Time, leapSecond bool, err error) {
+	t, fields, err := f.parseRaw(layout, value, parser)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	switch {
+	case !layout.hasDate && f.opts.defaultDate != nil:
+		d := f.opts.defaultDate
+		t = time.Date(d.Year(), d.Month(), d.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	case f.opts.referenceTime != nil:
+		t = f.opts.referenceTime.fill(&layout, t)
+	}
+	if !layout.hasYear && f.opts.referenceTime != nil {
+		// The date is complete only now.
+		if err := f.opts.checkDate(value, t, fields); err != nil {
+			return time.Time{}, false, err
+		}
+	}
+	final := t
+	if len(tags) > 0 {
+		// The suffix may move t into its time zone. Its errors are reported by parseMatched.
+		if suffixed, msg := f.opts.applySuffix(t, &layout, tags); msg == "" {
+			final = suffixed
+		}
+	}
+	if err := f.opts.checkBounds(final); err != nil {
+		return time.Time{}, false, err
+	}
+	return t, fields.leapSecond, nil
+}
+
+// parseRaw parses value in layout. Absent fields are left as time.Parse does.
+// It also returns fields of value time.Time does not hold, e.g. whether value had a leap second.
+func (f *Flextime) parseRaw(
+	layout compiledLayout,
+	value string,
+	parser func(layout, value string) (time.Time, error),
+) (time.Time, scannedFields, error) {
+	if !layout.hasExt && !f.opts.needsRewrite() {
+		t, err := parser(layout.layout, value)
+		return t, scannedFields{}, err
+	}
+
+	s := newScanner(&f.opts, layout.layout, value)
+	if err := s.scan(layout.chunks); err != nil {
+		return time.Time{}, scannedFields{}, err
+	}
+	t, err := parser(s.outLayout.String(), s.outValue.String())
+	if err != nil {
+		return time.Time{}, scannedFields{}, s.remapError(err)
+	}
+	var msg string
+	if t, msg = s.adjust(t); msg != "" {
+		return time.Time{}, scannedFields{}, &time.ParseError{Layout: layout.layout, Value: value, Message: ": " + msg}
+	}
+	if layout.hasYear {
+		// checked before converted into the location, as the date is the one in the offset of the value.
+		if err := f.opts.checkDate(value, t, s.fields); err != nil {
+			return time.Time{}, scannedFields{}, err
 		}
 	}
-	return time.Time{}, lastErr
+	if loc := s.fields.location; loc != nil {
+		var ok bool
+		if t, ok, err = f.opts.applyLocation(t, loc, layout.hasOffset, true); err != nil {
+			return time.Time{}, scannedFields{}, err
+		} else if !ok {
+			return time.Time{}, scannedFields{}, &time.ParseError{
+				Layout:  layout.layout,
+				Value:   value,
+				Message: ": offset is inconsistent with time zone " + loc.String(),
+			}
+		}
+	}
+	if s.fields.leapSecond && !isLeapSecondInstant(t, f.opts.leapSecond) {
+		return time.Time{}, scannedFields{}, &time.ParseError{
+			Layout:  layout.layout,
+			Value:   value,
+			Message: ": leap second must be at 23:59:60 UTC",
+		}
+	}
+	return t, s.fields, nil
 }
 
 func (f *Flextime) Parse(value string) (time.Time, error) {
@@ -35,14 +252,43 @@ func (f *Flextime) Parse(value string) (time.Time, error) {
 }
 
 func (f *Flextime) ParseInLocation(value string, loc *time.Location) (time.Time, error) {
-	return f.parse(
-		value,
-		func(
-			layout, value string,
-		) (time.Time, error) {
+	return f.parse(value, f.inLocation(loc))
+}
+
+// inLocation returns a parser which parses values in loc.
+// Local times without an offset are resolved by the DST policy.
+func (f *Flextime) inLocation(loc *time.Location) func(layout, value string) (time.Time, error) {
+	if f.opts.dstPolicy == DSTDefault {
+		return func(layout, value string) (time.Time, error) {
 			return time.ParseInLocation(layout, value, loc)
-		},
-	)
+		}
+	}
+	return func(layout, value string) (time.Time, error) {
+		if hasOffsetChunk(splitLayout(layout)) {
+			return time.ParseInLocation(layout, value, loc)
+		}
+		// The time package would normalize the local time, so it is parsed as is in UTC first.
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return resolveLocal(t, loc, f.opts.dstPolicy)
+	}
+}
+
+// Format returns a textual representation of t in the longest layout of the LayoutSet.
+func (f *Flextime) Format(t time.Time) string {
+	layout := f.compiled[0]
+	var formatted string
+	if !layout.hasExt && !f.opts.needsRewrite() {
+		formatted = t.Format(layout.layout)
+	} else {
+		formatted = formatChunks(&f.opts, layout.chunks, t)
+	}
+	if f.opts.rfc9557 {
+		formatted += formatRFC9557Suffix(t)
+	}
+	return formatted
 }
 
 func (p *Flextime) LayoutSet() *LayoutSet {
@@ -50,7 +296,5 @@ func (p *Flextime) LayoutSet() *LayoutSet {
 }
 
 func (p *Flextime) AddLayout(other *LayoutSet) *Flextime {
-	return &Flextime{
-		layouts: p.layouts.AddLayout(other),
-	}
+	return newFlextime(p.layouts.AddLayout(other), p.opts)
 }
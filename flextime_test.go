@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/ngicks/flextime"
+	"github.com/ngicks/flextime/locale"
 	"github.com/stretchr/testify/require"
 )
 
@@ -29,3 +30,117 @@ func TestFlextime(t *testing.T) {
 		return time.Date(2022, time.October, 20, 23, 16, 22, 168000000, jst).Equal(parsed)
 	})
 }
+
+type localeTestCase struct {
+	locale   *locale.Locale
+	layout   string
+	input    string
+	expected time.Time
+}
+
+func TestFlextimeLocale(t *testing.T) {
+	cases := []localeTestCase{
+		{
+			locale:   locale.DE,
+			layout:   `ww, D. MMMM YYYY HH:mm`,
+			input:    "Sonntag, 2. Januar 2022 15:04",
+			expected: time.Date(2022, 1, 2, 15, 4, 0, 0, time.UTC),
+		},
+		{
+			locale:   locale.DE,
+			layout:   `w DD. MMM YYYY`,
+			input:    "Mi 02. Mär 2022",
+			expected: time.Date(2022, 3, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			locale:   locale.FR,
+			layout:   `ww Do MMMM YYYY`,
+			input:    "mardi 1er février 2022",
+			expected: time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			locale:   locale.FR,
+			layout:   `w D MMM YYYY`,
+			input:    "lun. 7 févr. 2022",
+			expected: time.Date(2022, 2, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			locale:   locale.ES,
+			layout:   `ww, D 'de' MMMM 'de' YYYY h:mm A`,
+			input:    "miércoles, 2 de marzo de 2022 3:04 p. m.",
+			expected: time.Date(2022, 3, 2, 15, 4, 0, 0, time.UTC),
+		},
+		{
+			locale:   locale.JA,
+			layout:   `YYYY'年'MMMMD'日'(w) Ah'時'mm'分'`,
+			input:    "2022年1月2日(日) 午後3時04分",
+			expected: time.Date(2022, 1, 2, 15, 4, 0, 0, time.UTC),
+		},
+		{
+			locale:   locale.EN,
+			layout:   `MMMM Do, YYYY`,
+			input:    "January 22nd, 2022",
+			expected: time.Date(2022, 1, 22, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, testCase := range cases {
+		// Do is an extended token.
+		l, err := flextime.NewExtendedLayoutSet(testCase.layout)
+		require.NoError(t, err)
+		p := flextime.NewFlextime(l, flextime.WithLocale(testCase.locale))
+
+		parsed, err := p.ParseInLocation(testCase.input, time.UTC)
+		require.NoError(t, err, testCase.input)
+		require.True(t, testCase.expected.Equal(parsed), "expected = %s, actual = %s", testCase.expected, parsed)
+		require.Equal(t, testCase.input, p.Format(parsed))
+	}
+}
+
+func TestFlextimeLocaleError(t *testing.T) {
+	l, err := flextime.NewSingleLayout(`D MMMM YYYY`)
+	require.NoError(t, err)
+	p := flextime.NewFlextime(l, flextime.WithLocale(locale.DE))
+
+	var parseErr *time.ParseError
+	_, err = p.Parse("2 enero 2022")
+	require.ErrorAs(t, err, &parseErr)
+	require.Equal(t, "enero 2022", parseErr.ValueElem)
+
+	// error reported by time.Parse refers to the input, not to the translated value.
+	_, err = p.Parse("32 Januar 2022")
+	require.ErrorAs(t, err, &parseErr)
+	require.Equal(t, "32 Januar 2022", parseErr.Value)
+}
+
+func TestFlextimeCaseInsensitive(t *testing.T) {
+	l, err := flextime.NewLayoutSet(`[w, ]DD MMM YYYY`)
+	require.NoError(t, err)
+	withTime, err := flextime.NewSingleLayout(`DD MMM YYYY hh:mm A`)
+	require.NoError(t, err)
+	withZone, err := flextime.NewSingleLayout(`DD MMM YYYY hh:mm A MST`)
+	require.NoError(t, err)
+	p := flextime.NewFlextime(
+		l.AddLayout(withTime).AddLayout(withZone).AddLayout(flextime.RFC3339Optinal),
+		flextime.WithCaseInsensitive(),
+	)
+
+	cases := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"2022-01-02t15:04:05.123z", time.Date(2022, 1, 2, 15, 4, 5, 123000000, time.UTC)},
+		{"2022-01-02T15:04:05+09:00", time.Date(2022, 1, 2, 15, 4, 5, 0, jst)},
+		{"SUN, 02 JAN 2022", time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"02 jan 2022 03:04 Pm", time.Date(2022, 1, 2, 15, 4, 0, 0, time.UTC)},
+		{"02 Jan 2022 03:04 am utc", time.Date(2022, 1, 2, 3, 4, 0, 0, time.UTC)},
+	}
+	for _, testCase := range cases {
+		parsed, err := p.ParseInLocation(testCase.input, time.UTC)
+		require.NoError(t, err, testCase.input)
+		require.True(t, testCase.expected.Equal(parsed), "expected = %s, actual = %s", testCase.expected, parsed)
+	}
+
+	_, err = flextime.NewFlextime(flextime.RFC3339Optinal).Parse("2022-01-02t15:04:05z")
+	require.Error(t, err)
+}
@@ -0,0 +1,212 @@
+package flextime
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interval is a time interval of ISO 8601, written as start/end, start/duration or duration/end,
+// e.g. 2022-01-02T15:04:05Z/PT1H.
+type Interval struct {
+	// Start and End are always set by ParseInterval. One of them is computed from the other if written with Duration.
+	Start time.Time
+	End   time.Time
+	// Duration is non nil if the interval is written with a duration.
+	Duration *Duration
+	// DurationFirst makes the interval written as duration/end instead of start/duration.
+	DurationFirst bool
+}
+
+// RepeatingInterval is a repeating interval of ISO 8601, e.g. R5/2022-01-02T15:04:05Z/PT1H.
+type RepeatingInterval struct {
+	// Repetitions is the number of repetitions. It is -1 for unbounded ones, written as R or R-1.
+	Repetitions int
+	Interval    Interval
+}
+
+const (
+	intervalLayout          = "ISO 8601 interval"
+	repeatingIntervalLayout = "ISO 8601 repeating interval"
+)
+
+// defaultIntervalEndpoint parses endpoints of intervals unmarshalled from text.
+var defaultIntervalEndpoint = NewFlextime(ISO8601)
+
+// ParseInterval parses an interval of ISO 8601 whose endpoints are parsed by endpoint,
+// e.g. a *Flextime or (*CombinedFlextime).StringParser.
+//
+// Endpoints may have / in them, e.g. 02/Jan/2006:15:04:05 -0700 of CommonLogFormat.
+// Each / is tried from the left as the separator, and the first one around which both endpoints parse is taken.
+// If none is, the error for the first / is returned.
+func ParseInterval(value string, endpoint StringParser) (Interval, error) {
+	if !strings.Contains(value, "/") {
+		return Interval{}, &time.ParseError{
+			Layout:     intervalLayout,
+			Value:      value,
+			LayoutElem: intervalLayout,
+			ValueElem:  value,
+			Message:    ": missing /",
+		}
+	}
+
+	var firstErr error
+	for i := 0; i < len(value); i++ {
+		if value[i] != '/' {
+			continue
+		}
+		parsed, err := parseIntervalAt(value, value[:i], value[i+1:], endpoint)
+		if err == nil {
+			return parsed, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return Interval{}, firstErr
+}
+
+// parseIntervalAt parses value as an interval of first and second separated by /.
+func parseIntervalAt(value, first, second string, endpoint StringParser) (Interval, error) {
+	isDuration := func(s string) bool {
+		return strings.HasPrefix(strings.TrimLeft(s, "+-"), "P")
+	}
+
+	switch {
+	case isDuration(first) && isDuration(second):
+		return Interval{}, &time.ParseError{
+			Layout:     intervalLayout,
+			Value:      value,
+			LayoutElem: intervalLayout,
+			ValueElem:  value,
+			Message:    ": both of start and end are durations",
+		}
+	case isDuration(first):
+		d, err := ParseDuration(first)
+		if err != nil {
+			return Interval{}, err
+		}
+		end, err := endpoint.Parse(second)
+		if err != nil {
+			return Interval{}, err
+		}
+		return Interval{Start: d.Negate().AddTo(end), End: end, Duration: &d, DurationFirst: true}, nil
+	case isDuration(second):
+		start, err := endpoint.Parse(first)
+		if err != nil {
+			return Interval{}, err
+		}
+		d, err := ParseDuration(second)
+		if err != nil {
+			return Interval{}, err
+		}
+		return Interval{Start: start, End: d.AddTo(start), Duration: &d}, nil
+	}
+
+	start, err := endpoint.Parse(first)
+	if err != nil {
+		return Interval{}, err
+	}
+	end, err := endpoint.Parse(second)
+	if err != nil {
+		return Interval{}, err
+	}
+	return Interval{Start: start, End: end}, nil
+}
+
+// Format returns i in ISO 8601 whose endpoints are formatted by format, e.g. (*Flextime).Format.
+func (i Interval) Format(format func(time.Time) string) string {
+	switch {
+	case i.Duration != nil && i.DurationFirst:
+		return i.Duration.String() + "/" + format(i.End)
+	case i.Duration != nil:
+		return format(i.Start) + "/" + i.Duration.String()
+	}
+	return format(i.Start) + "/" + format(i.End)
+}
+
+// String returns i in ISO 8601 whose endpoints are formatted in RFC 3339 with nanoseconds.
+func (i Interval) String() string {
+	return i.Format(formatRFC3339Nano)
+}
+
+func (i Interval) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText parses text by ParseInterval with ISO8601 endpoints.
+func (i *Interval) UnmarshalText(text []byte) error {
+	parsed, err := ParseInterval(string(text), defaultIntervalEndpoint)
+	if err != nil {
+		return err
+	}
+	*i = parsed
+	return nil
+}
+
+// ParseRepeatingInterval parses a repeating interval of ISO 8601 whose endpoints are parsed by endpoint.
+func ParseRepeatingInterval(value string, endpoint StringParser) (RepeatingInterval, error) {
+	repetitions, interval, ok := strings.Cut(value, "/")
+	if !ok || !strings.HasPrefix(repetitions, "R") {
+		return RepeatingInterval{}, &time.ParseError{
+			Layout:     repeatingIntervalLayout,
+			Value:      value,
+			LayoutElem: repeatingIntervalLayout,
+			ValueElem:  value,
+			Message:    ": missing R",
+		}
+	}
+
+	n := -1
+	if repetitions != "R" && repetitions != "R-1" {
+		var err error
+		n, err = strconv.Atoi(repetitions[1:])
+		if err != nil || n < 0 {
+			return RepeatingInterval{}, &time.ParseError{
+				Layout:     repeatingIntervalLayout,
+				Value:      value,
+				LayoutElem: repeatingIntervalLayout,
+				ValueElem:  value,
+				Message:    ": invalid number of repetitions " + strconv.Quote(repetitions[1:]),
+			}
+		}
+	}
+
+	parsed, err := ParseInterval(interval, endpoint)
+	if err != nil {
+		return RepeatingInterval{}, err
+	}
+	return RepeatingInterval{Repetitions: n, Interval: parsed}, nil
+}
+
+// Format returns r in ISO 8601 whose endpoints are formatted by format.
+func (r RepeatingInterval) Format(format func(time.Time) string) string {
+	repetitions := "R"
+	if r.Repetitions >= 0 {
+		repetitions += strconv.Itoa(r.Repetitions)
+	}
+	return repetitions + "/" + r.Interval.Format(format)
+}
+
+// String returns r in ISO 8601 whose endpoints are formatted in RFC 3339 with nanoseconds.
+func (r RepeatingInterval) String() string {
+	return r.Format(formatRFC3339Nano)
+}
+
+func (r RepeatingInterval) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText parses text by ParseRepeatingInterval with ISO8601 endpoints.
+func (r *RepeatingInterval) UnmarshalText(text []byte) error {
+	parsed, err := ParseRepeatingInterval(string(text), defaultIntervalEndpoint)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+func formatRFC3339Nano(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,118 @@
+package flextime_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterval(t *testing.T) {
+	start := time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC)
+	end := time.Date(2022, 1, 2, 16, 4, 5, 0, time.UTC)
+	hour := flextime.Duration{Hours: 1}
+	f := flextime.NewFlextime(flextime.RFC3339)
+
+	for _, testCase := range []struct {
+		input    string
+		expected flextime.Interval
+	}{
+		{"2022-01-02T15:04:05Z/2022-01-02T16:04:05Z", flextime.Interval{Start: start, End: end}},
+		{"2022-01-02T15:04:05Z/PT1H", flextime.Interval{Start: start, End: end, Duration: &hour}},
+		{"PT1H/2022-01-02T16:04:05Z", flextime.Interval{Start: start, End: end, Duration: &hour, DurationFirst: true}},
+	} {
+		parsed, err := flextime.ParseInterval(testCase.input, f)
+		require.NoError(t, err, testCase.input)
+		assert.Equal(t, testCase.expected, parsed, testCase.input)
+		assert.Equal(t, testCase.input, parsed.Format(f.Format), testCase.input)
+	}
+
+	// endpoints are parsed by any StringParser.
+	parsed, err := flextime.ParseInterval("2022-01-02/P1D", flextime.RFC3339orUnixMilli.StringParser())
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC), parsed.End)
+
+	// endpoints may have / in them.
+	clf := flextime.NewFlextime(flextime.CommonLogFormat)
+	clfStart := time.Date(2000, 10, 10, 13, 55, 36, 0, time.FixedZone("", -7*60*60))
+	for _, testCase := range []struct {
+		input    string
+		expected flextime.Interval
+	}{
+		{
+			"10/Oct/2000:13:55:36 -0700/10/Oct/2000:14:55:36 -0700",
+			flextime.Interval{Start: clfStart, End: clfStart.Add(time.Hour)},
+		},
+		{
+			"10/Oct/2000:13:55:36 -0700/PT1H",
+			flextime.Interval{Start: clfStart, End: clfStart.Add(time.Hour), Duration: &hour},
+		},
+		{
+			"PT1H/10/Oct/2000:14:55:36 -0700",
+			flextime.Interval{Start: clfStart, End: clfStart.Add(time.Hour), Duration: &hour, DurationFirst: true},
+		},
+	} {
+		parsed, err := flextime.ParseInterval(testCase.input, clf)
+		require.NoError(t, err, testCase.input)
+		assert.True(t, testCase.expected.Start.Equal(parsed.Start), testCase.input)
+		assert.True(t, testCase.expected.End.Equal(parsed.End), testCase.input)
+		assert.Equal(t, testCase.expected.Duration, parsed.Duration, testCase.input)
+		assert.Equal(t, testCase.expected.DurationFirst, parsed.DurationFirst, testCase.input)
+	}
+
+	for _, invalid := range []string{"2022-01-02T15:04:05Z", "PT1H/PT1H", "2022-01-02T15:04:05Z/P1", "P1/2022-01-02T15:04:05Z", "foo/2022-01-02T15:04:05Z", "2022-01-02T15:04:05Z/2022-01-02T16:04:05Z/"} {
+		_, err := flextime.ParseInterval(invalid, f)
+		var parseErr *time.ParseError
+		assert.ErrorAs(t, err, &parseErr, invalid)
+	}
+}
+
+func TestRepeatingInterval(t *testing.T) {
+	f := flextime.NewFlextime(flextime.RFC3339)
+	for _, testCase := range []struct {
+		input       string
+		repetitions int
+		formatted   string
+	}{
+		{"R5/2022-01-02T15:04:05Z/PT1H", 5, ""},
+		{"R0/2022-01-02T15:04:05Z/PT1H", 0, ""},
+		{"R/2022-01-02T15:04:05Z/PT1H", -1, ""},
+		{"R-1/2022-01-02T15:04:05Z/PT1H", -1, "R/2022-01-02T15:04:05Z/PT1H"},
+	} {
+		parsed, err := flextime.ParseRepeatingInterval(testCase.input, f)
+		require.NoError(t, err, testCase.input)
+		assert.Equal(t, testCase.repetitions, parsed.Repetitions, testCase.input)
+		assert.Equal(t, time.Date(2022, 1, 2, 16, 4, 5, 0, time.UTC), parsed.Interval.End, testCase.input)
+		formatted := testCase.formatted
+		if formatted == "" {
+			formatted = testCase.input
+		}
+		assert.Equal(t, formatted, parsed.Format(f.Format), testCase.input)
+	}
+
+	for _, invalid := range []string{"5/2022-01-02T15:04:05Z/PT1H", "Rx/2022-01-02T15:04:05Z/PT1H", "R-2/2022-01-02T15:04:05Z/PT1H", "R5"} {
+		_, err := flextime.ParseRepeatingInterval(invalid, f)
+		var parseErr *time.ParseError
+		assert.ErrorAs(t, err, &parseErr, invalid)
+	}
+}
+
+func TestIntervalJSON(t *testing.T) {
+	type payload struct {
+		I flextime.Interval          `json:"i"`
+		R flextime.RepeatingInterval `json:"r"`
+	}
+	input := `{"i":"2022-01-02T15:04:05.5+09:00/PT1H","r":"R2/P1D/2022-01-03T00:00:00Z"}`
+	var p payload
+	require.NoError(t, json.Unmarshal([]byte(input), &p))
+	assert.True(t, time.Date(2022, 1, 2, 16, 4, 5, 500000000, jst).Equal(p.I.End))
+	assert.Equal(t, 2, p.R.Repetitions)
+	assert.True(t, time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC).Equal(p.R.Interval.Start))
+
+	bin, err := json.Marshal(p)
+	require.NoError(t, err)
+	assert.Equal(t, input, string(bin))
+}
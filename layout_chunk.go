@@ -0,0 +1,202 @@
+package flextime
+
+import "strings"
+
+type chunkKind int
+
+const (
+	// literalChunk is a run of characters which must appear in a value as is.
+	literalChunk chunkKind = iota
+	// stdChunk is one of Go's std time layout tokens, e.g. "2006", "Jan" or ".000".
+	stdChunk
+	// extChunk is a flextime extension token, written as {{name}} in a Go layout.
+	// time.Parse can not handle these. value of the chunk is its name.
+	extChunk
+)
+
+const (
+	extTokenOpen  = "{{"
+	extTokenClose = "}}"
+)
+
+type layoutChunk struct {
+	kind  chunkKind
+	value string
+}
+
+func (c layoutChunk) String() string {
+	if c.kind == extChunk {
+		return extTokenOpen + c.value + extTokenClose
+	}
+	return c.value
+}
+
+// isFrac reports whether c is fractional seconds std token, e.g. ".000" or ",999".
+func (c layoutChunk) isFrac() bool {
+	return c.kind == stdChunk && len(c.value) > 1 && (c.value[0] == '.' || c.value[0] == ',')
+}
+
+func extToken(name string) string {
+	return extTokenOpen + name + extTokenClose
+}
+
+// literalBrace is the name of the extension token for a literal {.
+const literalBrace = "{"
+
+// escapeLiteral escapes { of literals of flextime layouts which could begin {{ in the Go layout,
+// those followed by { or at the end, so that literals never make extension tokens.
+func escapeLiteral(lit string) string {
+	if !strings.Contains(lit, "{") {
+		return lit
+	}
+	var b strings.Builder
+	for i := 0; i < len(lit); i++ {
+		if lit[i] == '{' && (i == len(lit)-1 || lit[i+1] == '{') {
+			b.WriteString(extToken(literalBrace))
+			continue
+		}
+		b.WriteByte(lit[i])
+	}
+	return b.String()
+}
+
+// splitLayout splits a Go time layout into chunks.
+// It recognizes std tokens the same way as the time package does.
+func splitLayout(layout string) []layoutChunk {
+	var chunks []layoutChunk
+	for len(layout) > 0 {
+		prefix, chunk, suffix, found := nextLayoutChunk(layout)
+		if len(prefix) > 0 {
+			chunks = append(chunks, layoutChunk{kind: literalChunk, value: prefix})
+		}
+		if !found {
+			break
+		}
+		chunks = append(chunks, chunk)
+		layout = suffix
+	}
+	return chunks
+}
+
+func hasExtChunk(chunks []layoutChunk) bool {
+	for _, c := range chunks {
+		if c.kind == extChunk {
+			return true
+		}
+	}
+	return false
+}
+
+// nextLayoutChunk is a port of nextStdChunk of the time package,
+// with the addition of extension tokens.
+func nextLayoutChunk(layout string) (prefix string, chunk layoutChunk, suffix string, found bool) {
+	std := func(i, n int) (string, layoutChunk, string, bool) {
+		return layout[:i], layoutChunk{kind: stdChunk, value: layout[i : i+n]}, layout[i+n:], true
+	}
+	hasAt := func(i int, s string) bool {
+		return strings.HasPrefix(layout[i:], s)
+	}
+
+	for i := 0; i < len(layout); i++ {
+		switch c := layout[i]; c {
+		case '{':
+			if hasAt(i, extTokenOpen) {
+				if end := strings.Index(layout[i+len(extTokenOpen):], extTokenClose); end >= 0 {
+					name := layout[i+len(extTokenOpen) : i+len(extTokenOpen)+end]
+					return layout[:i],
+						layoutChunk{kind: extChunk, value: name},
+						layout[i+len(extTokenOpen)+end+len(extTokenClose):],
+						true
+				}
+			}
+		case 'J': // January, Jan
+			if hasAt(i, "January") {
+				return std(i, 7)
+			}
+			if hasAt(i, "Jan") && !startsWithLowerCase(layout[i+3:]) {
+				return std(i, 3)
+			}
+		case 'M': // Monday, Mon, MST
+			if hasAt(i, "Monday") {
+				return std(i, 6)
+			}
+			if hasAt(i, "Mon") && !startsWithLowerCase(layout[i+3:]) {
+				return std(i, 3)
+			}
+			if hasAt(i, "MST") {
+				return std(i, 3)
+			}
+		case '0': // 01, 02, 03, 04, 05, 06, 002
+			if len(layout) >= i+2 && '1' <= layout[i+1] && layout[i+1] <= '6' {
+				return std(i, 2)
+			}
+			if hasAt(i, "002") {
+				return std(i, 3)
+			}
+		case '1': // 15, 1
+			if hasAt(i, "15") {
+				return std(i, 2)
+			}
+			return std(i, 1)
+		case '2': // 2006, 2
+			if hasAt(i, "2006") {
+				return std(i, 4)
+			}
+			return std(i, 1)
+		case '_': // _2, _2006, __2
+			if hasAt(i, "_2006") {
+				// _2006 is really a literal _, followed by 2006.
+				return std(i+1, 4)
+			}
+			if hasAt(i, "_2") {
+				return std(i, 2)
+			}
+			if hasAt(i, "__2") {
+				return std(i, 3)
+			}
+		case '3', '4', '5':
+			return std(i, 1)
+		case 'P': // PM
+			if hasAt(i, "PM") {
+				return std(i, 2)
+			}
+		case 'p': // pm
+			if hasAt(i, "pm") {
+				return std(i, 2)
+			}
+		case '-', 'Z':
+			for _, tz := range [...]string{"070000", "07:00:00", "0700", "07:00", "07"} {
+				if hasAt(i+1, tz) {
+					return std(i, 1+len(tz))
+				}
+			}
+		case '.', ',': // .000, .999, ,000, ,999
+			if i+1 < len(layout) && (layout[i+1] == '0' || layout[i+1] == '9') {
+				j := i + 1
+				for j < len(layout) && layout[j] == layout[i+1] {
+					j++
+				}
+				if !isDigit(layout, j) {
+					return std(i, j-i)
+				}
+			}
+		}
+	}
+	return layout, layoutChunk{}, "", false
+}
+
+func startsWithLowerCase(str string) bool {
+	if len(str) == 0 {
+		return false
+	}
+	c := str[0]
+	return 'a' <= c && c <= 'z'
+}
+
+func isDigit(s string, i int) bool {
+	if len(s) <= i {
+		return false
+	}
+	c := s[i]
+	return '0' <= c && c <= '9'
+}
@@ -29,6 +29,18 @@ func newLayoutSet(layouts []string) *LayoutSet {
 }
 
 func NewLayoutSet(optionalStr string) (*LayoutSet, error) {
+	return newLayoutSetFrom(optionalStr, false)
+}
+
+// NewExtendedLayoutSet is same as NewLayoutSet but also recognizes extended tokens,
+// Do, GGGG, GGGGG, E, EE, EEEE, z, zzzz, O, OOOO and N.
+// They are single letters or runs of a letter which may appear as literals in layouts,
+// e.g. E of EST, so letters meant as literals must be escaped, e.g. 'EST'.
+func NewExtendedLayoutSet(optionalStr string) (*LayoutSet, error) {
+	return newLayoutSetFrom(optionalStr, true)
+}
+
+func newLayoutSetFrom(optionalStr string, extended bool) (*LayoutSet, error) {
 	rawFormats, err := optionalstring.EnumerateOptionalStringRaw(optionalStr)
 	if err != nil {
 		return nil, err
@@ -36,7 +48,7 @@ func NewLayoutSet(optionalStr string) (*LayoutSet, error) {
 
 	layouts := make([]string, len(rawFormats))
 	for i := 0; i < len(rawFormats); i++ {
-		replaced, err := ReplaceTimeTokenRaw(rawFormats[i])
+		replaced, err := replaceTimeTokenRaw(rawFormats[i], extended)
 		if err != nil {
 			return nil, err
 		}
@@ -66,6 +78,16 @@ func (l *LayoutSet) Layout() []string {
 	return l.layouts
 }
 
+// wrap returns a LayoutSet whose layouts are those of l with Go layouts prefix and suffix added.
+// It is for predefined layouts which have extension tokens with no flextime token, e.g. week dates.
+func (l *LayoutSet) wrap(prefix, suffix string) *LayoutSet {
+	layouts := make([]string, len(l.layouts))
+	for i, layout := range l.layouts {
+		layouts[i] = prefix + layout + suffix
+	}
+	return newLayoutSet(layouts)
+}
+
 func (l *LayoutSet) AddLayout(other *LayoutSet) *LayoutSet {
 	setLayout := set.New[string]()
 	for _, v := range l.layouts {
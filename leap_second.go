@@ -0,0 +1,71 @@
+package flextime
+
+import "time"
+
+// LeapSecondPolicy decides how a leap second, 60 of seconds as in 23:59:60, is parsed.
+type LeapSecondPolicy int
+
+const (
+	// LeapSecondReject fails to parse leap seconds as the time package does.
+	LeapSecondReject LeapSecondPolicy = iota
+	// LeapSecondClamp takes a leap second as the last instant of the previous second, e.g. 23:59:59.999999999.
+	LeapSecondClamp
+	// LeapSecondRoll takes a leap second as the next second, e.g. 00:00:00 of the next day.
+	// Fractional seconds are kept, so 23:59:60.5 is 00:00:00.5.
+	LeapSecondRoll
+)
+
+// WithLeapSecond makes Flextime accept leap seconds and resolve them by p.
+// A leap second must be at 23:59:60 UTC after the offset of the value is applied; 60 of other minutes is an error.
+// ParseDetailed reports whether the value had a leap second.
+func WithLeapSecond(p LeapSecondPolicy) Option {
+	return optionFunc(func(o *options) {
+		o.leapSecond = p
+	})
+}
+
+// leapSecond is how a leap second is written.
+const leapSecond = "60"
+
+// resolveLeapSecond returns t, parsed with the second of 59 in place of a leap second, according to p.
+func resolveLeapSecond(t time.Time, p LeapSecondPolicy) time.Time {
+	if p == LeapSecondRoll {
+		return t.Add(time.Second)
+	}
+	return t.Add(time.Second - 1 - time.Duration(t.Nanosecond()))
+}
+
+// isLeapSecondInstant reports whether t, resolved from a leap second by p, is at 23:59:60 UTC,
+// the only time leap seconds are inserted.
+func isLeapSecondInstant(t time.Time, p LeapSecondPolicy) bool {
+	if p == LeapSecondRoll {
+		t = t.Add(-time.Second)
+	}
+	u := t.UTC()
+	return u.Hour() == 23 && u.Minute() == 59
+}
+
+// ParseResult is a result of parsing with details of the value.
+type ParseResult struct {
+	Time time.Time
+	// LeapSecond is true if the value had a leap second. Time is resolved by the LeapSecondPolicy.
+	LeapSecond bool
+}
+
+// ParseDetailed is same as Parse but also reports details of value.
+func (f *Flextime) ParseDetailed(value string) (ParseResult, error) {
+	return f.parseDetailed(value, func(layout, value string) (time.Time, error) { return time.Parse(layout, value) })
+}
+
+// ParseDetailedInLocation is same as ParseInLocation but also reports details of value.
+func (f *Flextime) ParseDetailedInLocation(value string, loc *time.Location) (ParseResult, error) {
+	return f.parseDetailed(value, f.inLocation(loc))
+}
+
+func (f *Flextime) parseDetailed(value string, parser func(layout, value string) (time.Time, error)) (ParseResult, error) {
+	t, m, err := f.parseMatched(value, parser)
+	if err != nil {
+		return ParseResult{}, err
+	}
+	return ParseResult{Time: f.opts.finish(t), LeapSecond: m.leapSecond}, nil
+}
@@ -0,0 +1,60 @@
+package flextime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeapSecond(t *testing.T) {
+	layouts, err := flextime.NewLayoutSet(`YYYY-MM-DDTHH:mm:ss[.999999999]Z`)
+	require.NoError(t, err)
+
+	_, err = flextime.NewFlextime(layouts).Parse("2016-12-31T23:59:60Z")
+	assert.Error(t, err)
+
+	for _, testCase := range []struct {
+		policy   flextime.LeapSecondPolicy
+		input    string
+		expected time.Time
+	}{
+		{flextime.LeapSecondClamp, "2016-12-31T23:59:60Z", time.Date(2016, 12, 31, 23, 59, 59, 999999999, time.UTC)},
+		{flextime.LeapSecondClamp, "2016-12-31T23:59:60.5Z", time.Date(2016, 12, 31, 23, 59, 59, 999999999, time.UTC)},
+		{flextime.LeapSecondClamp, "2017-01-01T08:59:60+09:00", time.Date(2017, 1, 1, 8, 59, 59, 999999999, jst)},
+		{flextime.LeapSecondRoll, "2016-12-31T23:59:60Z", time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{flextime.LeapSecondRoll, "2017-01-01T05:29:60+05:30", time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{flextime.LeapSecondRoll, "2016-12-31T23:59:60.5Z", time.Date(2017, 1, 1, 0, 0, 0, 500000000, time.UTC)},
+	} {
+		f := flextime.NewFlextime(layouts, flextime.WithLeapSecond(testCase.policy))
+
+		parsed, err := f.Parse(testCase.input)
+		require.NoError(t, err, "input = %s", testCase.input)
+		assert.True(t, testCase.expected.Equal(parsed), "input = %s, parsed = %s", testCase.input, parsed)
+
+		result, err := f.ParseDetailed(testCase.input)
+		require.NoError(t, err)
+		assert.True(t, result.LeapSecond)
+		assert.True(t, testCase.expected.Equal(result.Time))
+
+		result, err = f.ParseDetailed("2016-12-31T23:59:59Z")
+		require.NoError(t, err)
+		assert.False(t, result.LeapSecond)
+		assert.Equal(t, time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC), result.Time)
+
+		_, err = f.Parse("2016-12-31T23:59:61Z")
+		assert.Error(t, err)
+
+		for _, notLeapSecond := range []string{
+			"2016-12-31T12:34:60Z",
+			"2016-12-31T22:59:60Z",
+			"2016-12-31T23:59:60+09:00",
+		} {
+			_, err = f.Parse(notLeapSecond)
+			var parseErr *time.ParseError
+			assert.ErrorAs(t, err, &parseErr, "input = %s", notLeapSecond)
+		}
+	}
+}
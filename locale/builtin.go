@@ -0,0 +1,124 @@
+package locale
+
+// EN is English. Names are identical to the ones the time package uses.
+var EN = mustRegister(&Locale{
+	Name: "en",
+	Months: [12]string{
+		"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December",
+	},
+	ShortMonths: [12]string{
+		"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+		"Jul", "Aug", "Sep", "Oct", "Nov", "Dec",
+	},
+	Weekdays: [7]string{
+		"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
+	},
+	ShortWeekdays: [7]string{
+		"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat",
+	},
+	AM: "AM",
+	PM: "PM",
+	Ordinals: [31]string{
+		"st", "nd", "rd", "th", "th", "th", "th", "th", "th", "th",
+		"th", "th", "th", "th", "th", "th", "th", "th", "th", "th",
+		"st", "nd", "rd", "th", "th", "th", "th", "th", "th", "th",
+		"st",
+	},
+})
+
+// DE is German.
+var DE = mustRegister(&Locale{
+	Name: "de",
+	Months: [12]string{
+		"Januar", "Februar", "März", "April", "Mai", "Juni",
+		"Juli", "August", "September", "Oktober", "November", "Dezember",
+	},
+	ShortMonths: [12]string{
+		"Jan", "Feb", "Mär", "Apr", "Mai", "Jun",
+		"Jul", "Aug", "Sep", "Okt", "Nov", "Dez",
+	},
+	Weekdays: [7]string{
+		"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag",
+	},
+	ShortWeekdays: [7]string{
+		"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa",
+	},
+	AM:       "AM",
+	PM:       "PM",
+	Ordinals: repeat31("."),
+})
+
+// FR is French.
+var FR = mustRegister(&Locale{
+	Name: "fr",
+	Months: [12]string{
+		"janvier", "février", "mars", "avril", "mai", "juin",
+		"juillet", "août", "septembre", "octobre", "novembre", "décembre",
+	},
+	ShortMonths: [12]string{
+		"janv.", "févr.", "mars", "avr.", "mai", "juin",
+		"juil.", "août", "sept.", "oct.", "nov.", "déc.",
+	},
+	Weekdays: [7]string{
+		"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi",
+	},
+	ShortWeekdays: [7]string{
+		"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam.",
+	},
+	AM:       "AM",
+	PM:       "PM",
+	Ordinals: [31]string{"er"},
+})
+
+// ES is Spanish.
+var ES = mustRegister(&Locale{
+	Name: "es",
+	Months: [12]string{
+		"enero", "febrero", "marzo", "abril", "mayo", "junio",
+		"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre",
+	},
+	ShortMonths: [12]string{
+		"ene", "feb", "mar", "abr", "may", "jun",
+		"jul", "ago", "sept", "oct", "nov", "dic",
+	},
+	Weekdays: [7]string{
+		"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado",
+	},
+	ShortWeekdays: [7]string{
+		"dom", "lun", "mar", "mié", "jue", "vie", "sáb",
+	},
+	AM:       "a. m.",
+	PM:       "p. m.",
+	Ordinals: repeat31("º"),
+})
+
+// JA is Japanese.
+var JA = mustRegister(&Locale{
+	Name: "ja",
+	Months: [12]string{
+		"1月", "2月", "3月", "4月", "5月", "6月",
+		"7月", "8月", "9月", "10月", "11月", "12月",
+	},
+	ShortMonths: [12]string{
+		"1月", "2月", "3月", "4月", "5月", "6月",
+		"7月", "8月", "9月", "10月", "11月", "12月",
+	},
+	Weekdays: [7]string{
+		"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日",
+	},
+	ShortWeekdays: [7]string{
+		"日", "月", "火", "水", "木", "金", "土",
+	},
+	AM:       "午前",
+	PM:       "午後",
+	Ordinals: repeat31("日"),
+})
+
+func repeat31(s string) [31]string {
+	var out [31]string
+	for i := range out {
+		out[i] = s
+	}
+	return out
+}
@@ -0,0 +1,133 @@
+// Package locale provides month and weekday names, AM/PM markers
+// and ordinal suffixes used to parse and format non-English date strings.
+package locale
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Locale is a table of localized names.
+type Locale struct {
+	// Name is an identifier of the locale, e.g. "de".
+	Name string
+	// Months is full month names, January first.
+	Months [12]string
+	// ShortMonths is abbreviated month names, January first.
+	ShortMonths [12]string
+	// Weekdays is full weekday names, Sunday first as time.Weekday does.
+	Weekdays [7]string
+	// ShortWeekdays is abbreviated weekday names, Sunday first as time.Weekday does.
+	ShortWeekdays [7]string
+	AM            string
+	PM            string
+	// Ordinals is suffixes for day of month, 1st first.
+	// It may be left empty if the locale has no ordinal suffix.
+	Ordinals [31]string
+}
+
+func (l *Locale) Month(m time.Month) string {
+	return l.Months[m-1]
+}
+
+func (l *Locale) ShortMonth(m time.Month) string {
+	return l.ShortMonths[m-1]
+}
+
+func (l *Locale) Weekday(w time.Weekday) string {
+	return l.Weekdays[w]
+}
+
+func (l *Locale) ShortWeekday(w time.Weekday) string {
+	return l.ShortWeekdays[w]
+}
+
+// Ordinal returns ordinal suffix for day of month.
+func (l *Locale) Ordinal(day int) string {
+	if day < 1 || day > len(l.Ordinals) {
+		return ""
+	}
+	return l.Ordinals[day-1]
+}
+
+// LookupMonth finds a full month name at the head of value.
+// The longest matching name wins. Comparison is case-insensitive, as the time package does for English names.
+func (l *Locale) LookupMonth(value string) (m time.Month, length int, ok bool) {
+	idx, length, ok := lookup(l.Months[:], value)
+	return time.Month(idx + 1), length, ok
+}
+
+// LookupShortMonth finds an abbreviated month name at the head of value.
+func (l *Locale) LookupShortMonth(value string) (m time.Month, length int, ok bool) {
+	idx, length, ok := lookup(l.ShortMonths[:], value)
+	return time.Month(idx + 1), length, ok
+}
+
+// LookupWeekday finds a full weekday name at the head of value.
+func (l *Locale) LookupWeekday(value string) (w time.Weekday, length int, ok bool) {
+	idx, length, ok := lookup(l.Weekdays[:], value)
+	return time.Weekday(idx), length, ok
+}
+
+// LookupShortWeekday finds an abbreviated weekday name at the head of value.
+func (l *Locale) LookupShortWeekday(value string) (w time.Weekday, length int, ok bool) {
+	idx, length, ok := lookup(l.ShortWeekdays[:], value)
+	return time.Weekday(idx), length, ok
+}
+
+// LookupOrdinal reports length of an ordinal suffix for day at the head of value.
+// It returns 0 and true if the locale has no suffix for the day.
+func (l *Locale) LookupOrdinal(day int, value string) (length int, ok bool) {
+	suffix := l.Ordinal(day)
+	if len(value) < len(suffix) || !strings.EqualFold(value[:len(suffix)], suffix) {
+		return 0, false
+	}
+	return len(suffix), true
+}
+
+func lookup(names []string, value string) (idx, length int, ok bool) {
+	idx = -1
+	for i, name := range names {
+		if name == "" || len(name) <= length || len(value) < len(name) {
+			continue
+		}
+		if strings.EqualFold(value[:len(name)], name) {
+			idx, length = i, len(name)
+		}
+	}
+	return idx, length, idx >= 0
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*Locale{}
+)
+
+// Register registers l under l.Name so that it can be retrieved by Get.
+// It replaces a formerly registered locale with the same name.
+func Register(l *Locale) error {
+	if l == nil || l.Name == "" {
+		return fmt.Errorf("locale: empty name")
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[strings.ToLower(l.Name)] = l
+	return nil
+}
+
+// Get returns a registered locale. name is case-insensitive.
+func Get(name string) (*Locale, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	l, ok := registry[strings.ToLower(name)]
+	return l, ok
+}
+
+func mustRegister(l *Locale) *Locale {
+	if err := Register(l); err != nil {
+		panic(err)
+	}
+	return l
+}
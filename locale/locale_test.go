@@ -0,0 +1,62 @@
+package locale_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime/locale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	m, n, ok := locale.FR.LookupMonth("JUILLET 2022")
+	require.True(t, ok)
+	assert.Equal(t, time.July, m)
+	assert.Equal(t, len("juillet"), n)
+
+	// the longest name wins.
+	m, n, ok = locale.ES.LookupShortMonth("sept 2022")
+	require.True(t, ok)
+	assert.Equal(t, time.September, m)
+	assert.Equal(t, len("sept"), n)
+
+	w, _, ok := locale.JA.LookupWeekday("水曜日")
+	require.True(t, ok)
+	assert.Equal(t, time.Wednesday, w)
+
+	_, _, ok = locale.DE.LookupMonth("January")
+	assert.True(t, ok, "Januar is a prefix of January")
+	_, _, ok = locale.DE.LookupMonth("Enero")
+	assert.False(t, ok)
+
+	n, ok = locale.EN.LookupOrdinal(23, "rd")
+	assert.True(t, ok)
+	assert.Equal(t, 2, n)
+	_, ok = locale.EN.LookupOrdinal(23, "th")
+	assert.False(t, ok)
+	n, ok = locale.FR.LookupOrdinal(2, " février")
+	assert.True(t, ok)
+	assert.Equal(t, 0, n)
+}
+
+func TestRegister(t *testing.T) {
+	it := &locale.Locale{
+		Name: "it",
+		Months: [12]string{
+			"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno",
+			"luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre",
+		},
+	}
+	require.NoError(t, locale.Register(it))
+
+	l, ok := locale.Get("IT")
+	require.True(t, ok)
+	assert.Same(t, it, l)
+
+	de, ok := locale.Get("de")
+	require.True(t, ok)
+	assert.Same(t, locale.DE, de)
+
+	assert.Error(t, locale.Register(&locale.Locale{}))
+}
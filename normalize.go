@@ -0,0 +1,97 @@
+package flextime
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// WithNormalization makes Flextime and CombinedFlextime fold look-alike characters of input into ASCII
+// before matching: full-width ASCII variants (e.g. ２０２２), U+2212 MINUS SIGN, dashes and Unicode spaces.
+// Reported errors still refer to the original input.
+func WithNormalization() CombinedOption {
+	return combinedOptionFunc(func(o *options) {
+		o.normalize = true
+	})
+}
+
+// normalizedValue is a value where look-alike characters are folded into ASCII.
+type normalizedValue struct {
+	original string
+	value    string
+	// offsets maps byte positions of value to ones of original. It has len(value)+1 elements.
+	// It is nil if value is same as original.
+	offsets []int
+}
+
+func normalize(original string) normalizedValue {
+	var b strings.Builder
+	var offsets []int
+	for i, r := range original {
+		folded := foldRune(r)
+		if offsets == nil {
+			if folded == r {
+				continue
+			}
+			b.WriteString(original[:i])
+			offsets = make([]int, i, len(original)+1)
+			for j := range offsets {
+				offsets[j] = j
+			}
+		}
+		if folded == r {
+			_, size := utf8.DecodeRuneInString(original[i:])
+			b.WriteString(original[i : i+size])
+			for j := 0; j < size; j++ {
+				offsets = append(offsets, i+j)
+			}
+			continue
+		}
+		n, _ := b.WriteRune(folded)
+		for j := 0; j < n; j++ {
+			offsets = append(offsets, i)
+		}
+	}
+	if offsets == nil {
+		return normalizedValue{original: original, value: original}
+	}
+	return normalizedValue{
+		original: original,
+		value:    b.String(),
+		offsets:  append(offsets, len(original)),
+	}
+}
+
+func foldRune(r rune) rune {
+	switch {
+	case '\uFF01' <= r && r <= '\uFF5E':
+		// Full-width ASCII variants.
+		return r - '\uFF01' + '!'
+	case r == '\u2212', // minus sign
+		r == '\uFE63',                  // small hyphen-minus
+		'\u2010' <= r && r <= '\u2015': // hyphen, non-breaking hyphen, figure dash, en dash, em dash and horizontal bar
+		return '-'
+	case r == '\u00A0', // no-break space
+		'\u2000' <= r && r <= '\u200A', // en quad through hair space
+		r == '\u202F',                  // narrow no-break space
+		r == '\u205F',                  // medium mathematical space
+		r == '\u3000':                  // ideographic space
+		return ' '
+	}
+	return r
+}
+
+// remapError rewrites time.ParseError reported against the normalized value into one against the original.
+func (n normalizedValue) remapError(err error) error {
+	var parseErr *time.ParseError
+	if n.offsets == nil || !errors.As(err, &parseErr) {
+		return err
+	}
+	remapped := *parseErr
+	remapped.Value = n.original
+	if strings.HasSuffix(n.value, parseErr.ValueElem) {
+		remapped.ValueElem = n.original[n.offsets[len(n.value)-len(parseErr.ValueElem)]:]
+	}
+	return &remapped
+}
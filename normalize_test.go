@@ -0,0 +1,66 @@
+package flextime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalization(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"２０２２－１０－２０Ｔ１６：２２：４６＋０９：００", time.Date(2022, 10, 20, 16, 22, 46, 0, jst)},
+		{"2022–10–20T16:22:46−09:00", time.Date(2022, 10, 20, 16, 22, 46, 0, time.FixedZone("", -9*60*60))},
+		{"2022-10-20", time.Date(2022, 10, 20, 0, 0, 0, 0, time.UTC)},
+	}
+
+	p := flextime.NewFlextime(flextime.RFC3339Optinal, flextime.WithNormalization())
+	c := flextime.NewCombined(
+		[]*flextime.Flextime{flextime.NewFlextime(flextime.RFC3339Optinal)},
+		time.UnixMilli,
+		flextime.WithNormalization(),
+	)
+	for _, testCase := range cases {
+		parsed, err := p.ParseInLocation(testCase.input, time.UTC)
+		require.NoError(t, err, testCase.input)
+		require.True(t, testCase.expected.Equal(parsed), "expected = %s, actual = %s", testCase.expected, parsed)
+
+		parsed, err = c.ParseInLocation(testCase.input, time.UTC)
+		require.NoError(t, err, testCase.input)
+		require.True(t, testCase.expected.Equal(parsed), "expected = %s, actual = %s", testCase.expected, parsed)
+	}
+
+	l, err := flextime.NewSingleLayout(`YYYY-MM-DD HH:mm`)
+	require.NoError(t, err)
+	p = flextime.NewFlextime(l, flextime.WithNormalization())
+	parsed, err := p.ParseInLocation("2022–01–02 15:04", time.UTC)
+	require.NoError(t, err)
+	require.True(t, time.Date(2022, 1, 2, 15, 4, 0, 0, time.UTC).Equal(parsed))
+
+	_, err = flextime.NewFlextime(l).Parse("２０２２-01-02 15:04")
+	require.Error(t, err)
+}
+
+func TestNormalizationError(t *testing.T) {
+	input := "２０２２－１０－２ａＴ１６"
+	var parseErr *time.ParseError
+
+	_, err := flextime.NewFlextime(flextime.RFC3339Optinal, flextime.WithNormalization()).Parse(input)
+	require.ErrorAs(t, err, &parseErr)
+	require.Equal(t, input, parseErr.Value)
+	require.Equal(t, "２ａＴ１６", parseErr.ValueElem)
+
+	c := flextime.NewCombined(
+		[]*flextime.Flextime{flextime.NewFlextime(flextime.RFC3339Optinal)},
+		nil,
+		flextime.WithNormalization(),
+	)
+	_, err = c.Parse(input)
+	require.ErrorAs(t, err, &parseErr)
+	require.Equal(t, input, parseErr.Value)
+	require.Equal(t, "２ａＴ１６", parseErr.ValueElem)
+}
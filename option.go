@@ -0,0 +1,121 @@
+package flextime
+
+import (
+	"time"
+
+	"github.com/ngicks/flextime/locale"
+)
+
+// Option is an optional configuration of Flextime.
+type Option interface {
+	apply(o *options)
+}
+
+// CombinedOption is an Option which also configures CombinedFlextime.
+// Options which do not make sense for CombinedFlextime, e.g. WithLocale, are not CombinedOption,
+// so that passing them to NewCombined fails to compile. Configure parsers given to it with them instead.
+type CombinedOption interface {
+	Option
+	combined()
+}
+
+type optionFunc func(o *options)
+
+func (f optionFunc) apply(o *options) { f(o) }
+
+type combinedOptionFunc func(o *options)
+
+func (f combinedOptionFunc) apply(o *options) { f(o) }
+
+func (f combinedOptionFunc) combined() {}
+
+type options struct {
+	locale          *locale.Locale
+	caseInsensitive bool
+	eras            EraTable
+	calendar        *Calendar
+	normalize       bool
+	referenceTime   *ReferenceTime
+	zoneResolver    *ZoneResolver
+	// locationProvider loads locations of zone identifiers. time.LoadLocation is used if nil.
+	locationProvider LocationProvider
+	rfc9557          bool
+	dstPolicy        DSTPolicy
+	endOfDay         bool
+	endOfDayFormat   bool
+	leapSecond       LeapSecondPolicy
+	strictDate       bool
+	bounds           *Bounds
+	twoDigitYear     TwoDigitYear
+	trailingComment  bool
+	floatParser      func(float64) time.Time
+	outputLocation   *time.Location
+	// precision truncates or, if roundPrecision, rounds results unless zero.
+	precision      time.Duration
+	roundPrecision bool
+	// defaultDate fills the date of values whose layout has no date.
+	defaultDate *time.Time
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return o
+}
+
+// needsRewrite reports whether values must be rewritten before passed to time.Parse,
+// and formatted chunk by chunk instead of by time.Time.Format.
+func (o *options) needsRewrite() bool {
+	return o.locale != nil || o.caseInsensitive || o.twoDigitYear != nil ||
+		o.zoneResolver != nil || o.endOfDay || o.endOfDayFormat || o.leapSecond != LeapSecondReject ||
+		o.strictDate
+}
+
+func (o *options) localeOrDefault() *locale.Locale {
+	if o.locale != nil {
+		return o.locale
+	}
+	return locale.EN
+}
+
+func (o *options) erasOrDefault() EraTable {
+	if o.eras != nil {
+		return o.eras
+	}
+	return JapaneseEras
+}
+
+// WithLocale makes Flextime parse and format month names, weekday names,
+// AM/PM markers and ordinal suffixes in l instead of English.
+func WithLocale(l *locale.Locale) Option {
+	return optionFunc(func(o *options) {
+		o.locale = l
+	})
+}
+
+// WithCaseInsensitive makes Flextime match literals, AM/PM markers, zone abbreviations and
+// the Z of zone offsets case-insensitively, e.g. 2022-01-02t15:04:05z or 3:04 Am.
+// Month and weekday names are always case-insensitive as the time package does.
+func WithCaseInsensitive() Option {
+	return optionFunc(func(o *options) {
+		o.caseInsensitive = true
+	})
+}
+
+// withDefaultDate makes Flextime fill the date of values whose layout has only time with the date of year, month and day.
+func withDefaultDate(year int, month time.Month, day int) Option {
+	return optionFunc(func(o *options) {
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		o.defaultDate = &date
+	})
+}
+
+// WithFloatParser makes CombinedFlextime parse numbers by p instead of numParser, so that fractions are kept,
+// e.g. with FromExcelSerial or FromJulianDay. Integers are converted to float64.
+func WithFloatParser(p func(float64) time.Time) CombinedOption {
+	return combinedOptionFunc(func(o *options) {
+		o.floatParser = p
+	})
+}
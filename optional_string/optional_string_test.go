@@ -113,3 +113,37 @@ func TestOptionalNonClosing(t *testing.T) {
 		require.Error(t, err)
 	}
 }
+
+func TestEnumerateOptionalStringRaw(t *testing.T) {
+	cases := []variantsTestCases{
+		{
+			input: `YYYY[ HH[:mm]]`,
+			output: []string{
+				`YYYY HH:mm`,
+				`YYYY HH`,
+				`YYYY`,
+			},
+		},
+		{
+			input: `YYYY[' 'HH]\-MM`,
+			output: []string{
+				`YYYY HH-MM`,
+				`YYYY-MM`,
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(fmt.Sprintf("case: %s", testCase.input), func(t *testing.T) {
+			result, err := optionalstring.EnumerateOptionalStringRaw(testCase.input)
+			require.NoError(t, err)
+			unescaped := make([]string, len(result))
+			for i, r := range result {
+				unescaped[i] = r.Unescaped()
+			}
+			sort.Strings(unescaped)
+			sort.Strings(testCase.output)
+			assert.Equal(t, testCase.output, unescaped)
+		})
+	}
+}
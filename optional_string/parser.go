@@ -70,7 +70,8 @@ func EnumerateOptionalStringRaw(optionalString string) (enumerated []RawString,
 
 		ast := parsec.NewAST("optionalString", 100)
 		p := MakeOptionalStringParser(ast)
-		s := parsec.NewScanner([]byte(optionalString))
+		// Whitespaces are significant in optional strings. Never skip them.
+		s := parsec.NewScanner([]byte(optionalString)).SetWSPattern(`^[^\s\S]`)
 		node, _ = ast.Parsewith(p, s)
 	}()
 
@@ -144,7 +145,7 @@ func recursiveDecode(nodes []parsec.Queryable, ctx *treeNode) {
 				case NORMALCHARS:
 					ctx.AddValue(v.GetValue(), Normal)
 				case ESCAPEDCHAR:
-					ctx.AddValue(v.GetValue(), SingleQuoteEscaped)
+					ctx.AddValue(v.GetValue(), SlashEscaped)
 				default:
 					panic(fmt.Sprintf("incorrect implementation: %s, %s", v.GetName(), v.GetValue()))
 				}
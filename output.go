@@ -0,0 +1,44 @@
+package flextime
+
+import "time"
+
+// WithOutputLocation makes Flextime and CombinedFlextime return results in loc, e.g. time.UTC,
+// whatever location or offset values have.
+func WithOutputLocation(loc *time.Location) CombinedOption {
+	return combinedOptionFunc(func(o *options) {
+		o.outputLocation = loc
+	})
+}
+
+// WithTruncate makes Flextime and CombinedFlextime truncate results to a multiple of d as time.Time.Truncate does.
+// It replaces WithRound given before.
+func WithTruncate(d time.Duration) CombinedOption {
+	return combinedOptionFunc(func(o *options) {
+		o.precision = d
+		o.roundPrecision = false
+	})
+}
+
+// WithRound makes Flextime and CombinedFlextime round results to the nearest multiple of d as time.Time.Round does.
+// It replaces WithTruncate given before.
+func WithRound(d time.Duration) CombinedOption {
+	return combinedOptionFunc(func(o *options) {
+		o.precision = d
+		o.roundPrecision = true
+	})
+}
+
+// finish applies the output location and precision to t.
+func (o *options) finish(t time.Time) time.Time {
+	if o.outputLocation != nil {
+		t = t.In(o.outputLocation)
+	}
+	if o.precision > 0 {
+		if o.roundPrecision {
+			t = t.Round(o.precision)
+		} else {
+			t = t.Truncate(o.precision)
+		}
+	}
+	return t
+}
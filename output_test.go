@@ -0,0 +1,51 @@
+package flextime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputLocationAndPrecision(t *testing.T) {
+	layouts, err := flextime.NewLayoutSet(`YYYY-MM-DDTHH:mm:ss[.SSSSSSSSS]Z`)
+	require.NoError(t, err)
+
+	truncated := flextime.NewFlextime(layouts, flextime.WithOutputLocation(time.UTC), flextime.WithTruncate(time.Millisecond))
+	parsed, err := truncated.Parse("2022-01-02T15:04:05.123987654+09:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 1, 2, 6, 4, 5, 123000000, time.UTC), parsed)
+
+	parsed, err = truncated.ParseInLocation("2022-01-02T15:04:05.123987654+09:00", jst)
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, parsed.Location())
+
+	rounded := flextime.NewFlextime(layouts, flextime.WithTruncate(time.Millisecond), flextime.WithRound(time.Millisecond))
+	parsed, err = rounded.Parse("2022-01-02T15:04:05.123987654Z")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 1, 2, 15, 4, 5, 124000000, time.UTC), parsed)
+
+	c := flextime.NewCombined(
+		[]*flextime.Flextime{flextime.NewFlextime(layouts)},
+		func(i int64) time.Time { return time.Unix(0, i) },
+		flextime.WithOutputLocation(jst),
+		flextime.WithTruncate(time.Second),
+	)
+	expected := time.Date(2022, 1, 2, 15, 4, 5, 0, jst)
+	for _, input := range []any{
+		"2022-01-02T06:04:05.999Z",
+		[]byte(`"2022-01-02T06:04:05.999Z"`),
+		time.Date(2022, 1, 2, 6, 4, 5, 999000000, time.UTC).UnixNano(),
+		[]byte("1641103445999000000"),
+	} {
+		parsed, err := c.Parse(input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, parsed, "input = %v", input)
+		assert.Equal(t, jst, parsed.Location())
+	}
+	parsed, err = c.StringParser().Parse("2022-01-02T06:04:05.999Z")
+	require.NoError(t, err)
+	assert.Equal(t, expected, parsed)
+}
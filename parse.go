@@ -19,13 +19,18 @@ func (e *FormatError) Error() string {
 }
 
 func ReplaceTimeTokenRaw(input optionalstring.RawString) (string, error) {
+	return replaceTimeTokenRaw(input, false)
+}
+
+// replaceTimeTokenRaw is ReplaceTimeTokenRaw which also recognizes extended tokens if extended is true.
+func replaceTimeTokenRaw(input optionalstring.RawString, extended bool) (string, error) {
 	var output string
 	for _, vv := range input {
 		switch vv.Typ() {
 		case optionalstring.SingleQuoteEscaped, optionalstring.SlashEscaped:
-			output += vv.Unescaped()
+			output += escapeLiteral(vv.Unescaped())
 		case optionalstring.Normal:
-			replaced, err := ReplaceTimeToken(vv.Unescaped())
+			replaced, err := replaceTimeToken(vv.Unescaped(), extended)
 			if err != nil {
 				return "", err
 			}
@@ -36,6 +41,10 @@ func ReplaceTimeTokenRaw(input optionalstring.RawString) (string, error) {
 }
 
 func ReplaceTimeToken(input string) (string, error) {
+	return replaceTimeToken(input, false)
+}
+
+func replaceTimeToken(input string, extended bool) (string, error) {
 	var prefix, token string
 	var isToken bool
 	var err error
@@ -43,15 +52,15 @@ func ReplaceTimeToken(input string) (string, error) {
 	var output string
 
 	for len(input) > 0 {
-		prefix, token, input, isToken, err = nextChunk(input)
+		prefix, token, input, isToken, err = nextChunk(input, extended)
 		if err != nil {
 			return "", err
 		}
-		output += prefix
+		output += escapeLiteral(prefix)
 		if isToken {
 			output += timeFormatToken(token).toGoFmt()
 		} else {
-			output += token
+			output += escapeLiteral(token)
 		}
 	}
 
@@ -64,7 +73,8 @@ func ReplaceTimeToken(input string) (string, error) {
 // found is next chunk string. If isTokein is true, chunk is a time token, an unescaped string otherwise.
 // suffix is rest of input.
 // err would be non nil if token has wrong length.
-func nextChunk(input string) (prefix string, found string, suffix string, isToken bool, err error) {
+// Tokens of extendedTokenSearchTable are recognized only if extended is true.
+func nextChunk(input string, extended bool) (prefix string, found string, suffix string, isToken bool, err error) {
 	for i := 0; i < len(input); i++ {
 		switch input[i] {
 		case '\\':
@@ -81,7 +91,22 @@ func nextChunk(input string) (prefix string, found string, suffix string, isToke
 			return input[:i], unescaped, input[i+len(`'`+unescaped+`'`):], false, nil
 		}
 
+		if n := len(input[i:]) - len(strings.TrimLeft(input[i:], "Y")); n >= minExpandedYearDigits {
+			if n > maxExpandedYearDigits {
+				return "", "", "", false, &FormatError{
+					idx:      i,
+					expected: fmt.Sprintf("expanded year of %d to %d Y", minExpandedYearDigits, maxExpandedYearDigits),
+					actual:   input[i : i+n],
+					msg:      "too many digits.",
+				}
+			}
+			return input[:i], input[i : i+n], input[i+n:], true, nil
+		}
+
 		possibleSequences, ok := tokenSerachTable[input[i]]
+		if extendedSequences, isExtended := extendedTokenSearchTable[input[i]]; extended && isExtended {
+			possibleSequences, ok = extendedSequences, true
+		}
 		if ok {
 			for _, possible := range possibleSequences {
 				if strings.HasPrefix(string(input[i:]), string(possible)) {
@@ -135,6 +160,8 @@ var tokenSerachTable = map[byte][]timeFormatToken{
 	'h': {"hh", "h"},
 	'm': {"mm", "m"},
 	's': {"ss", "s"},
+	// Runs of 5 through 9 Y are expanded years, which nextChunk reads before this table.
+	// They were errors of wrong length, so they do not change existing layouts.
 	'Y': {"YYYY", "YY"},
 	'y': {"yyyy", "yy"},
 	'A': {"A"},
@@ -146,6 +173,18 @@ var tokenSerachTable = map[byte][]timeFormatToken{
 	// single '.' is non-token.
 }
 
+// extendedTokenSearchTable has tokens recognized only by NewExtendedLayoutSet.
+// They are letters which may appear in literals of existing layouts, e.g. E of EST, N of Noon, z of zulu or o after D,
+// so NewLayoutSet leaves them as literals.
+var extendedTokenSearchTable = map[byte][]timeFormatToken{
+	'D': {"DDD", "DD", "Do", "D"},
+	'G': {"GGGGG", "GGGG"},
+	'E': {"EEEE", "EE", "E"},
+	'z': {"zzzz", "z"},
+	'O': {"OOOO", "O"},
+	'N': {"N"},
+}
+
 var tokenTable = map[timeFormatToken]goTimeFmtToken{
 	"MMMM":      "January",
 	"MMM":       "Jan",
@@ -156,6 +195,7 @@ var tokenTable = map[timeFormatToken]goTimeFmtToken{
 	"D":         "2",
 	"d":         "2",
 	"DD":        "02",
+	"Do":        "{{Do}}",
 	"dd":        "02",
 	"DDD":       "002",
 	"ddd":       "002",
@@ -170,9 +210,19 @@ var tokenTable = map[timeFormatToken]goTimeFmtToken{
 	"yyyy":      "2006",
 	"YY":        "06",
 	"yy":        "06",
+	"GGGG":      "{{GGGG}}",
+	"GGGGG":     "{{GGGGG}}",
+	"E":         "{{E}}",
+	"EE":        "{{EE}}",
+	"EEEE":      "{{EEEE}}",
 	"A":         "PM",
 	"a":         "pm",
 	"MST":       "MST",
+	"z":         "{{z}}",
+	"zzzz":      "{{zzzz}}",
+	"O":         "{{O}}",
+	"OOOO":      "{{OOOO}}",
+	"N":         "{{N}}",
 	"ZZ":        "Z0700",
 	"Z070000":   "Z070000",
 	"Z07":       "Z07",
@@ -197,6 +247,7 @@ var tokens = [...]timeFormatToken{
 	"ddd",
 	"dd",
 	"d",
+	"Do",
 	"HH",
 	"hh",
 	"h",
@@ -204,11 +255,23 @@ var tokens = [...]timeFormatToken{
 	"m",
 	"ss",
 	"s",
+	"YYYYYY",
+	"YYYYY",
 	"YYYY",
 	"YY",
+	"GGGGG",
+	"GGGG",
+	"EEEE",
+	"EE",
+	"E",
 	"A",
 	"a",
 	"MST",
+	"zzzz",
+	"z",
+	"OOOO",
+	"O",
+	"N",
 	"Z07:00:00",
 	"Z070000",
 	"Z07",
@@ -265,6 +328,9 @@ func (tt timeFormatToken) toGoFmt() string {
 	if ok {
 		return string(token)
 	}
+	if isExpandedYearToken(string(tt)) {
+		return extToken(string(tt))
+	}
 
 	if strings.HasPrefix(string(tt), ".S") {
 		return strings.ReplaceAll(string(tt), "S", "0")
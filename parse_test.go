@@ -6,6 +6,7 @@ import (
 
 	"github.com/ngicks/flextime"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type replaceTimeTokenTestCase struct {
@@ -43,3 +44,65 @@ func TestReplaceTimeToken(t *testing.T) {
 		assert.Equal(t, testCase.expected, out)
 	}
 }
+
+func TestLiteralLetters(t *testing.T) {
+	for _, testCase := range []struct {
+		input    string
+		expected []string
+	}{
+		{`YYYY-MM-DD HH:mm EST`, []string{`2006-01-02 15:04 EST`}},
+		{`[Noon ]HH:mm`, []string{`Noon 15:04`, `15:04`}},
+		{`OK`, []string{`OK`}},
+		{`zulu`, []string{`zulu`}},
+		// M has been a token from the start, so only G is literal here.
+		{`YYYY GMT`, []string{`2006 G1T`}},
+		{`YYYY G`, []string{`2006 G`}},
+		{`GGGG`, []string{`GGGG`}},
+		{`Do`, []string{`2o`}},
+	} {
+		l, err := flextime.NewLayoutSet(testCase.input)
+		require.NoError(t, err, testCase.input)
+		assert.Equal(t, testCase.expected, l.Layout(), testCase.input)
+	}
+
+	for _, testCase := range []struct {
+		input    string
+		expected []string
+	}{
+		{`YYYY-MM-DD HH:mm 'EST'`, []string{`2006-01-02 15:04 EST`}},
+		{`GGGGE年`, []string{`{{GGGG}}{{E}}年`}},
+		{`MMMM Do`, []string{`January {{Do}}`}},
+		{`HH:mm z`, []string{`15:04 {{z}}`}},
+		{`HH:mm zzzz`, []string{`15:04 {{zzzz}}`}},
+		{`HH:mm O OOOO N`, []string{`15:04 {{O}} {{OOOO}} {{N}}`}},
+	} {
+		l, err := flextime.NewExtendedLayoutSet(testCase.input)
+		require.NoError(t, err, testCase.input)
+		assert.Equal(t, testCase.expected, l.Layout(), testCase.input)
+	}
+
+	_, err := flextime.NewExtendedLayoutSet(`YYYY GMT`)
+	assert.Error(t, err)
+}
+
+func TestLiteralBraces(t *testing.T) {
+	// {{ in literals is not an extension token.
+	for _, testCase := range []struct {
+		layout string
+		value  string
+	}{
+		{`'{{x}}'YYYY`, `{{x}}2022`},
+		{`\{\{x}}YYYY`, `{{x}}2022`},
+		{`'{{Do}}' YYYY`, `{{Do}} 2022`},
+		{`'{'YYYY'}'`, `{2022}`},
+		{`'{'[Do ]YYYY`, `{1st 2022`},
+	} {
+		l, err := flextime.NewExtendedLayoutSet(testCase.layout)
+		require.NoError(t, err, testCase.layout)
+		f := flextime.NewFlextime(l)
+		parsed, err := f.Parse(testCase.value)
+		require.NoError(t, err, testCase.layout)
+		assert.Equal(t, 2022, parsed.Year(), testCase.layout)
+		assert.Equal(t, testCase.value, f.Format(parsed), testCase.layout)
+	}
+}
@@ -10,4 +10,169 @@ import (
 // And lower parts (hours, minutes, seconds, nanoseconds) and timezone offset are optional.
 var RFC3339Optinal *LayoutSet = typeparamcommon.Must(NewLayoutSet(`YYYY-MM-DD[THH[:mm[:ss.999999999]]][Z]`))
 
+// RFC3339Partial is RFC3339Optinal which also accepts year and year-month, e.g. 2022 or 2022-03.
+// It is meant for (*Flextime).ParseRange.
+var RFC3339Partial *LayoutSet = typeparamcommon.Must(NewLayoutSet(`YYYY[-MM]`)).AddLayout(RFC3339Optinal)
+
 var RFC3339orUnixMilli *CombinedFlextime = NewCombined([]*Flextime{NewFlextime(RFC3339Optinal)}, time.UnixMilli)
+
+// JapaneseDate is LayoutSet for dates written with kanji, e.g. 2022年1月2日 15時04分05秒.
+// Time and seconds are optional.
+var JapaneseDate *LayoutSet = typeparamcommon.Must(NewLayoutSet(`YYYY年M月D日[ HH時mm分[ss秒]]`))
+
+// JapaneseEraDate is LayoutSet for dates in the Japanese calendar, e.g. 令和4年1月2日 15時04分05秒.
+// Time and seconds are optional.
+var JapaneseEraDate *LayoutSet = typeparamcommon.Must(NewExtendedLayoutSet(`GGGGE年M月D日[ HH時mm分[ss秒]]`))
+
+// JapaneseEraAbbrDate is LayoutSet for dates in the Japanese calendar with abbreviated era names, e.g. R4.01.02.
+var JapaneseEraAbbrDate *LayoutSet = typeparamcommon.Must(NewExtendedLayoutSet(`GGGGGE.MM.DD`))
+
+// RFC1123Date is LayoutSet for IMF-fixdate of HTTP, e.g. Sun, 06 Nov 1994 08:49:37 GMT.
+var RFC1123Date *LayoutSet = typeparamcommon.Must(NewLayoutSet(`w, DD MMM YYYY HH:mm:ss MST`))
+
+// RFC850Date is LayoutSet for obsolete RFC 850 format of HTTP, e.g. Sunday, 06-Nov-94 08:49:37 GMT.
+var RFC850Date *LayoutSet = typeparamcommon.Must(NewLayoutSet(`ww, DD-MMM-YY HH:mm:ss MST`))
+
+// ANSICDate is LayoutSet for asctime() format of HTTP, e.g. Sun Nov  6 08:49:37 1994.
+// A space in the layout matches to one or more spaces, so that the space padded day is accepted.
+var ANSICDate *LayoutSet = typeparamcommon.Must(NewLayoutSet(`w MMM D HH:mm:ss YYYY`))
+
+// HTTPDate is LayoutSet for the three HTTP-date formats defined in RFC 9110, section 5.6.7.
+var HTTPDate *LayoutSet = RFC1123Date.AddLayout(RFC850Date).AddLayout(ANSICDate)
+
+// RFC5322Date is LayoutSet for date-time of Internet Message Format, e.g. Fri, 21 Nov 1997 09:55:06 -0600.
+// Weekday and seconds are optional. Obsolete zone names, like EST or PDT, are resolved by RFC5322ZoneAbbrs,
+// and other alphabetic zones, like IST or military letters, are -0000 as RFC 5322 says.
+// Zone names are resolved regardless of WithZoneResolver.
+var RFC5322Date *LayoutSet = typeparamcommon.Must(NewLayoutSet(`[w, ]D MMM YYYY HH:mm[:ss] -0700`)).
+	AddLayout(typeparamcommon.Must(NewLayoutSet(`[w, ]D MMM YYYY HH:mm[:ss] `)).wrap("", extToken("obs-zone")))
+
+// ISO8601Extended is LayoutSet for calendar, ordinal and week dates of ISO 8601 in the extended format,
+// e.g. 2022-01-02T15:04:05.123+09:00, 2022-002T15:04 or 2022-W01-1T15:04.
+// Week dates must have the day of the week.
+var ISO8601Extended *LayoutSet = typeparamcommon.Must(NewLayoutSet(`YYYY-MM-DD[THH[:mm[:ss.999999999]]][Z]`)).
+	AddLayout(typeparamcommon.Must(NewLayoutSet(`YYYY-DDD[THH[:mm[:ss.999999999]]][Z]`))).
+	AddLayout(typeparamcommon.Must(NewLayoutSet(`[THH[:mm[:ss.999999999]]][Z]`)).wrap(extToken(weekDateExtended), ""))
+
+// ISO8601Basic is LayoutSet for calendar, ordinal and week dates of ISO 8601 in the basic format,
+// e.g. 20220102T150405.123+0900, 2022002T1504 or 2022W011T1504.
+// Week dates must have the day of the week.
+var ISO8601Basic *LayoutSet = typeparamcommon.Must(NewLayoutSet(`YYYYMMDD[THH[mm[ss.999999999]]][ZZ]`)).
+	AddLayout(typeparamcommon.Must(NewLayoutSet(`YYYYDDD[THH[mm[ss.999999999]]][ZZ]`))).
+	AddLayout(typeparamcommon.Must(NewLayoutSet(`[THH[mm[ss.999999999]]][ZZ]`)).wrap(extToken(weekDateBasic), ""))
+
+// ISO8601 is LayoutSet for both of the extended and the basic format of ISO 8601.
+var ISO8601 *LayoutSet = ISO8601Extended.AddLayout(ISO8601Basic)
+
+// RFC3339 is LayoutSet for date-time of RFC 3339, e.g. 2022-01-02T15:04:05.123+09:00.
+var RFC3339 *LayoutSet = typeparamcommon.Must(NewLayoutSet(`YYYY-MM-DDTHH:mm:ss.999999999Z`))
+
+// RFC9557Parser parses RFC3339 with suffixes of RFC 9557, e.g. 2022-01-02T15:04:05+09:00[Asia/Tokyo].
+var RFC9557Parser *Flextime = NewFlextime(RFC3339, WithRFC9557Suffix())
+
+var (
+	HTTPDateParser *CombinedFlextime = NewCombined([]*Flextime{NewFlextime(HTTPDate)}, nil)
+	// RFC5322DateParser parses RFC5322Date. Letters are matched case-insensitively as RFC 5322 does.
+	RFC5322DateParser  *CombinedFlextime = NewCombined([]*Flextime{NewFlextime(RFC5322Date, WithCaseInsensitive())}, nil)
+	ISO8601orUnixMilli *CombinedFlextime = NewCombined([]*Flextime{NewFlextime(ISO8601)}, time.UnixMilli)
+	// RFC3339AnyCaseOrUnixMilli parses RFC3339 with lowercase t and z, e.g. 2022-01-02t15:04:05z, which RFC 3339 allows.
+	RFC3339AnyCaseOrUnixMilli *CombinedFlextime = NewCombined(
+		[]*Flextime{NewFlextime(RFC3339, WithCaseInsensitive())},
+		time.UnixMilli,
+	)
+)
+
+// CommonLogFormat is LayoutSet for timestamps of Apache/nginx Common Log Format, e.g. 10/Oct/2000:13:55:36 -0700.
+var CommonLogFormat *LayoutSet = typeparamcommon.Must(NewLayoutSet(`DD/MMM/YYYY:HH:mm:ss -0700`))
+
+// RFC3164Syslog is LayoutSet for timestamps of BSD syslog, e.g. Oct 11 22:14:15 or Feb  5 17:32:18.
+// Since it has no year, use it with WithYearInference.
+var RFC3164Syslog *LayoutSet = typeparamcommon.Must(NewLayoutSet(`MMM D HH:mm:ss`))
+
+// RFC5424Syslog is LayoutSet for timestamps of syslog protocol, e.g. 2003-08-24T05:14:15.000003-07:00.
+var RFC5424Syslog *LayoutSet = typeparamcommon.Must(NewLayoutSet(`YYYY-MM-DDTHH:mm:ss.999999Z`))
+
+// DockerTimestamp is LayoutSet for timestamps of Docker and Kubernetes logs, e.g. 2022-10-20T07:22:46.123456789Z.
+// Docker writes RFC3339 with nanoseconds, so it is an alias of RFC3339, which accepts up to nanoseconds.
+var DockerTimestamp *LayoutSet = RFC3339
+
+// ELBTimestamp is LayoutSet for timestamps of AWS Classic, Application and Network Load Balancer access logs,
+// e.g. 2018-07-02T22:23:00.186641Z.
+var ELBTimestamp *LayoutSet = typeparamcommon.Must(NewLayoutSet(`YYYY-MM-DDTHH:mm:ss.999999Z`))
+
+// CloudWatchTimestamp is LayoutSet for @timestamp of AWS CloudWatch Logs Insights, e.g. 2022-10-20 07:22:46.123.
+var CloudWatchTimestamp *LayoutSet = typeparamcommon.Must(NewLayoutSet(`YYYY-MM-DD HH:mm:ss.999`))
+
+// CloudWatchOrUnixMilli parses CloudWatchTimestamp, ELBTimestamp or unix milli, which CloudWatch Logs uses for events.
+var CloudWatchOrUnixMilli *CombinedFlextime = NewCombined(
+	[]*Flextime{NewFlextime(CloudWatchTimestamp), NewFlextime(ELBTimestamp)},
+	time.UnixMilli,
+)
+
+// MySQLDateTime is LayoutSet for DATE, DATETIME(fsp) and TIMESTAMP(fsp) of MySQL, e.g. 2022-01-02 15:04:05.123456.
+var MySQLDateTime *LayoutSet = typeparamcommon.Must(NewLayoutSet(`YYYY-MM-DD[ HH:mm:ss.999999]`))
+
+// PostgresTimestamp is LayoutSet for date, timestamp and timestamptz of PostgreSQL in the ISO DateStyle,
+// e.g. 2022-01-02 15:04:05.123+09 or 1900-01-01 00:00:00+09:18:59.
+// PostgreSQL omits minutes and seconds of an offset if they are zero.
+var PostgresTimestamp *LayoutSet = typeparamcommon.Must(NewLayoutSet(`YYYY-MM-DD[ HH:mm:ss.999999[-07]]`)).
+	AddLayout(typeparamcommon.Must(NewLayoutSet(`YYYY-MM-DD HH:mm:ss.999999-07:00`))).
+	AddLayout(typeparamcommon.Must(NewLayoutSet(`YYYY-MM-DD HH:mm:ss.999999-07:00:00`)))
+
+// SQLiteDate is LayoutSet for time values of the date and time functions of SQLite,
+// e.g. 2022-01-02, 2022-01-02 15:04, 2022-01-02T15:04:05.123Z or 15:04:05+09:00.
+// Use it with SQLiteParser, which dates values with only time on 2000-01-01 as SQLite does.
+var SQLiteDate *LayoutSet = typeparamcommon.Must(NewLayoutSet(`YYYY-MM-DD[ HH:mm[:ss.999]][Z]`)).
+	AddLayout(typeparamcommon.Must(NewLayoutSet(`YYYY-MM-DDTHH:mm[:ss.999][Z]`))).
+	AddLayout(typeparamcommon.Must(NewLayoutSet(`HH:mm[:ss.999][Z]`)))
+
+// SQLiteParser parses SQLiteDate.
+var SQLiteParser *Flextime = NewFlextime(SQLiteDate, withDefaultDate(2000, time.January, 1))
+
+// SQLServerDateTime is LayoutSet for date, datetime, datetime2 and datetimeoffset of SQL Server,
+// e.g. 2022-01-02 15:04:05.1234567 +09:00, and the default style of CONVERT, e.g. Jan  2 2022  3:04PM.
+var SQLServerDateTime *LayoutSet = typeparamcommon.Must(NewLayoutSet(`YYYY-MM-DD[ HH:mm:ss.9999999][ -07:00]`)).
+	AddLayout(typeparamcommon.Must(NewLayoutSet(`MMM D YYYY h:mmA`)))
+
+// OracleDate is LayoutSet for the default NLS_DATE_FORMAT and NLS_TIMESTAMP_TZ_FORMAT of Oracle Database,
+// e.g. 02-JAN-22 or 02-JAN-22 03.04.05.123456000 PM +09:00.
+// Use it with OracleParser, which resolves two-digit years by the RR rule.
+var OracleDate *LayoutSet = typeparamcommon.Must(NewLayoutSet(`DD-MMM-YY[ hh.mm.ss.999999999 A[ -07:00]]`))
+
+// OracleParser parses OracleDate with the RR rule.
+var OracleParser *Flextime = NewFlextime(OracleDate, WithTwoDigitYear(OracleRR{}))
+
+// JavaScriptDate is LayoutSet for Date.prototype.toString of JavaScript,
+// e.g. Mon Jan 02 2006 15:04:05 GMT+0900 (Japan Standard Time).
+// Use it with WithTrailingComment, or JavaScriptDateParser, to ignore the zone name in parentheses.
+var JavaScriptDate *LayoutSet = typeparamcommon.Must(NewLayoutSet(`w MMM DD YYYY HH:mm:ss 'GMT'-0700`))
+
+// JavaScriptDateParser parses JavaScriptDate ignoring the trailing zone name.
+var JavaScriptDateParser *Flextime = NewFlextime(JavaScriptDate, WithTrailingComment())
+
+// JavaDate is LayoutSet for java.util.Date#toString of Java, e.g. Mon Jan 02 15:04:05 JST 2006.
+// Zones without an abbreviation are written as GMT+09:00.
+var JavaDate *LayoutSet = typeparamcommon.Must(NewExtendedLayoutSet(`w MMM DD HH:mm:ss z YYYY`)).
+	AddLayout(typeparamcommon.Must(NewLayoutSet(`w MMM DD HH:mm:ss 'GMT'-07:00 YYYY`)))
+
+// PythonDateTime is LayoutSet for str and isoformat of datetime and date of Python,
+// e.g. 2006-01-02 15:04:05.123456+09:00 or 2006-01-02T15:04:05.
+var PythonDateTime *LayoutSet = typeparamcommon.Must(NewLayoutSet(`YYYY-MM-DD[ HH:mm:ss.999999[-07:00]]`)).
+	AddLayout(typeparamcommon.Must(NewLayoutSet(`YYYY-MM-DDTHH:mm:ss.999999[-07:00]`)))
+
+// RubyTime is LayoutSet for Time#to_s of Ruby, e.g. 2006-01-02 15:04:05 +0900 or 2006-01-02 15:04:05 UTC.
+var RubyTime *LayoutSet = typeparamcommon.Must(NewLayoutSet(`YYYY-MM-DD HH:mm:ss -0700`)).
+	AddLayout(typeparamcommon.Must(NewLayoutSet(`YYYY-MM-DD HH:mm:ss MST`)))
+
+// RuntimeDateParser parses default stringifications of JavaScript, Java, Python, .NET and Ruby,
+// or unix milli, which JavaScript uses for Date.
+var RuntimeDateParser *CombinedFlextime = NewCombinedParsers(
+	[]StringParser{
+		JavaScriptDateParser,
+		NewFlextime(JavaDate),
+		NewFlextime(PythonDateTime),
+		DotNetJSONDate,
+		NewFlextime(RubyTime),
+	},
+	time.UnixMilli,
+)
@@ -0,0 +1,413 @@
+package flextime_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/require"
+)
+
+type predefinedTestCase struct {
+	input    string
+	expected time.Time
+}
+
+func testPredefined(t *testing.T, p interface {
+	ParseInLocation(v string, loc *time.Location) (time.Time, error)
+}, cases []predefinedTestCase) {
+	t.Helper()
+	for _, testCase := range cases {
+		parsed, err := p.ParseInLocation(testCase.input, time.UTC)
+		require.NoError(t, err, testCase.input)
+		require.True(
+			t,
+			testCase.expected.Equal(parsed),
+			"input = %s, expected = %s, actual = %s", testCase.input, testCase.expected, parsed,
+		)
+	}
+}
+
+type combinedStringParser struct {
+	c *flextime.CombinedFlextime
+}
+
+func (p combinedStringParser) ParseInLocation(v string, loc *time.Location) (time.Time, error) {
+	return p.c.ParseInLocation(v, loc)
+}
+
+func TestPredefinedInternetStandards(t *testing.T) {
+	testPredefined(t, combinedStringParser{flextime.HTTPDateParser}, []predefinedTestCase{
+		{"Sun, 06 Nov 1994 08:49:37 GMT", time.Date(1994, 11, 6, 8, 49, 37, 0, time.UTC)},
+		{"Sunday, 06-Nov-94 08:49:37 GMT", time.Date(1994, 11, 6, 8, 49, 37, 0, time.UTC)},
+		{"Sun Nov  6 08:49:37 1994", time.Date(1994, 11, 6, 8, 49, 37, 0, time.UTC)},
+		{"Thu Oct 20 16:22:46 2022", time.Date(2022, 10, 20, 16, 22, 46, 0, time.UTC)},
+	})
+
+	testPredefined(t, combinedStringParser{flextime.RFC5322DateParser}, []predefinedTestCase{
+		{"Fri, 21 Nov 1997 09:55:06 -0600", time.Date(1997, 11, 21, 9, 55, 6, 0, time.FixedZone("", -6*60*60))},
+		{"Tue, 1 Jul 2003 10:52:37 +0200", time.Date(2003, 7, 1, 10, 52, 37, 0, time.FixedZone("", 2*60*60))},
+		{"21 Nov 1997 09:55 -0600", time.Date(1997, 11, 21, 9, 55, 0, 0, time.FixedZone("", -6*60*60))},
+		{"Thu, 13 Feb 1969 23:32:54 -0330", time.Date(1969, 2, 13, 23, 32, 54, 0, time.FixedZone("", -(3*60+30)*60))},
+		{"Fri, 21 Nov 1997 09:55:06 EST", time.Date(1997, 11, 21, 9, 55, 6, 0, time.FixedZone("", -5*60*60))},
+		{"Fri, 21 Nov 1997 09:55:06 PDT", time.Date(1997, 11, 21, 9, 55, 6, 0, time.FixedZone("", -7*60*60))},
+		{"Fri, 21 Nov 1997 09:55:06 UT", time.Date(1997, 11, 21, 9, 55, 6, 0, time.UTC)},
+		{"fri, 21 nov 1997 09:55:06 gmt", time.Date(1997, 11, 21, 9, 55, 6, 0, time.UTC)},
+	})
+
+	testPredefined(t, combinedStringParser{flextime.ISO8601orUnixMilli}, []predefinedTestCase{
+		{"2022-01-02", time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2022-01-02T15:04:05.123+09:00", time.Date(2022, 1, 2, 15, 4, 5, 123000000, jst)},
+		{"2022-01-02T15Z", time.Date(2022, 1, 2, 15, 0, 0, 0, time.UTC)},
+		{"2022-032T15:04", time.Date(2022, 2, 1, 15, 4, 0, 0, time.UTC)},
+		{"20220102", time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"20220102T150405.123+0900", time.Date(2022, 1, 2, 15, 4, 5, 123000000, jst)},
+		{"20220102T1504Z", time.Date(2022, 1, 2, 15, 4, 0, 0, time.UTC)},
+		{"2022032T15", time.Date(2022, 2, 1, 15, 0, 0, 0, time.UTC)},
+		{"2022-W01-1", time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"2022-W01-1T15:04:05+09:00", time.Date(2022, 1, 3, 15, 4, 5, 0, jst)},
+		{"2020-W53-5", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2009-W01-1", time.Date(2008, 12, 29, 0, 0, 0, 0, time.UTC)},
+		{"2022W011", time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"2022W017T1504Z", time.Date(2022, 1, 9, 15, 4, 0, 0, time.UTC)},
+	})
+	for _, invalid := range []string{"2022-W53-1", "2022-W00-1", "2022-W01-8", "2022-W1-1", "2022-W01", "2022W01", "2022-W011"} {
+		_, err := flextime.NewFlextime(flextime.ISO8601).Parse(invalid)
+		var parseErr *time.ParseError
+		require.ErrorAs(t, err, &parseErr, invalid)
+	}
+	// week dates do not change Format.
+	require.Equal(
+		t,
+		"2022-01-03T15:04:05+09:00",
+		flextime.NewFlextime(flextime.ISO8601Extended).Format(time.Date(2022, 1, 3, 15, 4, 5, 0, jst)),
+	)
+	require.Equal(
+		t,
+		"20220103T150405+0900",
+		flextime.NewFlextime(flextime.ISO8601Basic).Format(time.Date(2022, 1, 3, 15, 4, 5, 0, jst)),
+	)
+
+	testPredefined(t, combinedStringParser{flextime.RFC3339AnyCaseOrUnixMilli}, []predefinedTestCase{
+		{"2022-01-02T15:04:05Z", time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"2022-01-02t15:04:05z", time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"2022-01-02t15:04:05.123456789+09:00", time.Date(2022, 1, 2, 15, 4, 5, 123456789, jst)},
+	})
+
+	parsed, err := flextime.ISO8601orUnixMilli.Parse(1641103445123)
+	require.NoError(t, err)
+	require.True(t, time.Date(2022, 1, 2, 15, 4, 5, 123000000, jst).Equal(parsed))
+
+	_, err = flextime.NewFlextime(flextime.RFC3339).Parse("2022-01-02")
+	require.Error(t, err)
+}
+
+func TestPredefinedLogFormats(t *testing.T) {
+	pdt := time.FixedZone("", -7*60*60)
+
+	// 127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326
+	testPredefined(t, flextime.NewFlextime(flextime.CommonLogFormat), []predefinedTestCase{
+		{"10/Oct/2000:13:55:36 -0700", time.Date(2000, 10, 10, 13, 55, 36, 0, pdt)},
+		{"02/Jan/2022:15:04:05 +0900", time.Date(2022, 1, 2, 15, 4, 5, 0, jst)},
+	})
+
+	// <34>Oct 11 22:14:15 mymachine su: 'su root' failed for lonvick on /dev/pts/8
+	now := func() time.Time { return time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC) }
+	testPredefined(
+		t,
+		flextime.NewFlextime(flextime.RFC3164Syslog, flextime.WithYearInference(flextime.YearInference{Now: now})),
+		[]predefinedTestCase{
+			{"Oct 11 22:14:15", time.Date(2022, 10, 11, 22, 14, 15, 0, time.UTC)},
+			{"Jan  5 17:32:18", time.Date(2023, 1, 5, 17, 32, 18, 0, time.UTC)},
+			{"Jan 15 00:00:00", time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)},
+			{"Dec 31 23:59:59", time.Date(2022, 12, 31, 23, 59, 59, 0, time.UTC)},
+		},
+	)
+
+	// <165>1 2003-08-24T05:14:15.000003-07:00 192.0.2.1 myproc 8710 - - %% It's time to make the do-nuts.
+	testPredefined(t, flextime.NewFlextime(flextime.RFC5424Syslog), []predefinedTestCase{
+		{"1985-04-12T23:20:50.52Z", time.Date(1985, 4, 12, 23, 20, 50, 520000000, time.UTC)},
+		{"1985-04-12T19:20:50.52-04:00", time.Date(1985, 4, 12, 23, 20, 50, 520000000, time.UTC)},
+		{"2003-10-11T22:14:15.003Z", time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC)},
+		{"2003-08-24T05:14:15.000003-07:00", time.Date(2003, 8, 24, 5, 14, 15, 3000, pdt)},
+	})
+
+	// 2022-10-20T07:22:46.123456789Z stdout F hello
+	testPredefined(t, flextime.NewFlextime(flextime.DockerTimestamp), []predefinedTestCase{
+		{"2022-10-20T07:22:46.123456789Z", time.Date(2022, 10, 20, 7, 22, 46, 123456789, time.UTC)},
+		{"2022-10-20T07:22:46.1234Z", time.Date(2022, 10, 20, 7, 22, 46, 123400000, time.UTC)},
+	})
+
+	// http 2018-07-02T22:23:00.186641Z app/my-loadbalancer/50dc6c495c0c9188 192.168.131.39:2817 ...
+	testPredefined(t, flextime.NewFlextime(flextime.ELBTimestamp), []predefinedTestCase{
+		{"2015-05-13T23:39:43.945958Z", time.Date(2015, 5, 13, 23, 39, 43, 945958000, time.UTC)},
+		{"2018-07-02T22:23:00.186641Z", time.Date(2018, 7, 2, 22, 23, 0, 186641000, time.UTC)},
+	})
+
+	testPredefined(t, combinedStringParser{flextime.CloudWatchOrUnixMilli}, []predefinedTestCase{
+		{"2022-10-20 07:22:46.123", time.Date(2022, 10, 20, 7, 22, 46, 123000000, time.UTC)},
+		{"2022-10-20T07:22:46.123Z", time.Date(2022, 10, 20, 7, 22, 46, 123000000, time.UTC)},
+	})
+	parsed, err := flextime.CloudWatchOrUnixMilli.Parse(1666250566123)
+	require.NoError(t, err)
+	require.True(t, time.Date(2022, 10, 20, 7, 22, 46, 123000000, time.UTC).Equal(parsed))
+}
+
+func TestPredefinedLogLines(t *testing.T) {
+	pdt := time.FixedZone("", -7*60*60)
+	now := func() time.Time { return time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC) }
+
+	// bracketed returns the text in the first pair of brackets, as the timestamp of Common Log Format.
+	bracketed := func(line string) string {
+		_, rest, _ := strings.Cut(line, "[")
+		timestamp, _, _ := strings.Cut(rest, "]")
+		return timestamp
+	}
+	// afterPRI returns n bytes after the PRI part, <34> of <34>Oct 11 22:14:15, as the timestamp of RFC 3164 is fixed width.
+	afterPRI := func(n int) func(line string) string {
+		return func(line string) string {
+			_, rest, _ := strings.Cut(line, ">")
+			return rest[:n]
+		}
+	}
+	// field returns the i-th field separated by spaces.
+	field := func(i int) func(line string) string {
+		return func(line string) string {
+			return strings.Fields(line)[i]
+		}
+	}
+
+	for _, testCase := range []struct {
+		name     string
+		parser   flextime.StringParser
+		extract  func(line string) string
+		line     string
+		expected time.Time
+	}{
+		{
+			"Common Log Format",
+			flextime.NewFlextime(flextime.CommonLogFormat),
+			bracketed,
+			`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326`,
+			time.Date(2000, 10, 10, 13, 55, 36, 0, pdt),
+		},
+		{
+			"Combined Log Format",
+			flextime.NewFlextime(flextime.CommonLogFormat),
+			bracketed,
+			`192.0.2.10 - - [02/Jan/2022:15:04:05 +0900] "GET /index.html HTTP/1.1" 200 512 "-" "curl/7.81.0"`,
+			time.Date(2022, 1, 2, 15, 4, 5, 0, jst),
+		},
+		{
+			"RFC 3164",
+			flextime.NewFlextime(flextime.RFC3164Syslog, flextime.WithYearInference(flextime.YearInference{Now: now})),
+			afterPRI(len("Mmm dd hh:mm:ss")),
+			`<34>Oct 11 22:14:15 mymachine su: 'su root' failed for lonvick on /dev/pts/8`,
+			time.Date(2022, 10, 11, 22, 14, 15, 0, time.UTC),
+		},
+		{
+			"RFC 3164 with a space padded day",
+			flextime.NewFlextime(flextime.RFC3164Syslog, flextime.WithYearInference(flextime.YearInference{Now: now})),
+			afterPRI(len("Mmm dd hh:mm:ss")),
+			`<13>Jan  5 17:32:18 10.0.0.99 myproc[8710]: Use the BFG!`,
+			time.Date(2023, 1, 5, 17, 32, 18, 0, time.UTC),
+		},
+		{
+			"RFC 5424",
+			flextime.NewFlextime(flextime.RFC5424Syslog),
+			field(1),
+			`<165>1 2003-08-24T05:14:15.000003-07:00 192.0.2.1 myproc 8710 - - %% It's time to make the do-nuts.`,
+			time.Date(2003, 8, 24, 5, 14, 15, 3000, pdt),
+		},
+		{
+			"RFC 5424 in UTC",
+			flextime.NewFlextime(flextime.RFC5424Syslog),
+			field(1),
+			`<34>1 2003-10-11T22:14:15.003Z mymachine.example.com su - ID47 - BOM'su root' failed for lonvick on /dev/pts/8`,
+			time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC),
+		},
+		{
+			"Kubernetes container log",
+			flextime.NewFlextime(flextime.DockerTimestamp),
+			field(0),
+			`2022-10-20T07:22:46.123456789Z stdout F hello`,
+			time.Date(2022, 10, 20, 7, 22, 46, 123456789, time.UTC),
+		},
+		{
+			"docker logs --timestamps",
+			flextime.NewFlextime(flextime.DockerTimestamp),
+			field(0),
+			`2022-10-20T07:22:46.1234Z hello`,
+			time.Date(2022, 10, 20, 7, 22, 46, 123400000, time.UTC),
+		},
+		{
+			"Application Load Balancer",
+			flextime.NewFlextime(flextime.ELBTimestamp),
+			field(1),
+			`http 2018-07-02T22:23:00.186641Z app/my-loadbalancer/50dc6c495c0c9188 192.168.131.39:2817 10.0.0.1:80 ` +
+				`0.000 0.001 0.000 200 200 34 366 "GET http://www.example.com:80/ HTTP/1.1" "curl/7.46.0" - - ` +
+				`arn:aws:elasticloadbalancing:us-east-2:123456789012:targetgroup/my-targets/73e2d6bc24d8a067 ` +
+				`"Root=1-58337262-36d228ad5d99923122bbe354" "-" "-" 0 2018-07-02T22:22:48.364000Z "forward" "-" "-" ` +
+				`"10.0.0.1:80" "200" "-" "-"`,
+			time.Date(2018, 7, 2, 22, 23, 0, 186641000, time.UTC),
+		},
+		{
+			"Classic Load Balancer",
+			flextime.NewFlextime(flextime.ELBTimestamp),
+			field(0),
+			`2015-05-13T23:39:43.945958Z my-loadbalancer 192.168.131.39:2817 10.0.0.1:80 0.000073 0.001048 0.000057 ` +
+				`200 200 0 29 "GET http://www.example.com:80/ HTTP/1.1" "curl/7.38.0" - -`,
+			time.Date(2015, 5, 13, 23, 39, 43, 945958000, time.UTC),
+		},
+	} {
+		parsed, err := testCase.parser.Parse(testCase.extract(testCase.line))
+		require.NoError(t, err, testCase.name)
+		require.True(
+			t,
+			testCase.expected.Equal(parsed),
+			"%s: expected = %s, actual = %s", testCase.name, testCase.expected, parsed,
+		)
+	}
+}
+
+func TestPredefinedDatabases(t *testing.T) {
+	testPredefined(t, flextime.NewFlextime(flextime.MySQLDateTime), []predefinedTestCase{
+		{"2022-01-02", time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2022-01-02 15:04:05", time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"2022-01-02 15:04:05.123456", time.Date(2022, 1, 2, 15, 4, 5, 123456000, time.UTC)},
+	})
+
+	testPredefined(t, flextime.NewFlextime(flextime.PostgresTimestamp), []predefinedTestCase{
+		{"2022-01-02", time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2022-01-02 15:04:05.123", time.Date(2022, 1, 2, 15, 4, 5, 123000000, time.UTC)},
+		{"2022-01-02 15:04:05.123+09", time.Date(2022, 1, 2, 15, 4, 5, 123000000, jst)},
+		{"2022-01-02 15:04:05+05:30", time.Date(2022, 1, 2, 15, 4, 5, 0, time.FixedZone("", 5*60*60+30*60))},
+		{"1900-01-01 00:00:00+09:18:59", time.Date(1900, 1, 1, 0, 0, 0, 0, time.FixedZone("", 9*60*60+18*60+59))},
+	})
+
+	testPredefined(t, flextime.SQLiteParser, []predefinedTestCase{
+		{"2022-01-02", time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2022-01-02 15:04", time.Date(2022, 1, 2, 15, 4, 0, 0, time.UTC)},
+		{"2022-01-02 15:04:05.123", time.Date(2022, 1, 2, 15, 4, 5, 123000000, time.UTC)},
+		{"2022-01-02T15:04:05Z", time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"2022-01-02 15:04:05+09:00", time.Date(2022, 1, 2, 15, 4, 5, 0, jst)},
+		{"15:04", time.Date(2000, 1, 1, 15, 4, 0, 0, time.UTC)},
+		{"15:04:05.123+09:00", time.Date(2000, 1, 1, 15, 4, 5, 123000000, jst)},
+	})
+
+	testPredefined(t, flextime.NewFlextime(flextime.SQLServerDateTime), []predefinedTestCase{
+		{"2022-01-02", time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2022-01-02 15:04:05.123", time.Date(2022, 1, 2, 15, 4, 5, 123000000, time.UTC)},
+		{"2022-01-02 15:04:05.1234567 +09:00", time.Date(2022, 1, 2, 15, 4, 5, 123456700, jst)},
+		{"Jan  2 2022  3:04PM", time.Date(2022, 1, 2, 15, 4, 0, 0, time.UTC)},
+	})
+
+	testPredefined(t, flextime.OracleParser, []predefinedTestCase{
+		{"02-JAN-22 03.04.05.123456000 PM", time.Date(2022, 1, 2, 15, 4, 5, 123456000, time.UTC)},
+		{"02-JAN-22 03.04.05.123456000 PM +09:00", time.Date(2022, 1, 2, 15, 4, 5, 123456000, jst)},
+	})
+}
+
+func TestOracleRR(t *testing.T) {
+	for _, testCase := range []struct {
+		now      int
+		input    string
+		expected int
+	}{
+		{2023, "02-JAN-22", 2022},
+		{2023, "02-JAN-49", 2049},
+		{2023, "02-JAN-50", 1950},
+		{2023, "02-JAN-99", 1999},
+		{2051, "02-JAN-22", 2122},
+		{2051, "02-JAN-49", 2149},
+		{2051, "02-JAN-50", 2050},
+		{2051, "02-JAN-99", 2099},
+	} {
+		now := time.Date(testCase.now, 1, 1, 0, 0, 0, 0, time.UTC)
+		p := flextime.NewFlextime(
+			flextime.OracleDate,
+			flextime.WithTwoDigitYear(flextime.OracleRR{Now: func() time.Time { return now }}),
+		)
+		parsed, err := p.Parse(testCase.input)
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, testCase.expected, parsed.Year(), "now = %d, input = %s", testCase.now, testCase.input)
+	}
+}
+
+func TestPredefinedRuntimes(t *testing.T) {
+	pst := time.FixedZone("", -8*60*60)
+
+	testPredefined(t, flextime.JavaScriptDateParser, []predefinedTestCase{
+		{"Mon Jan 02 2006 15:04:05 GMT+0900 (Japan Standard Time)", time.Date(2006, 1, 2, 15, 4, 5, 0, jst)},
+		{"Mon Jan 02 2006 15:04:05 GMT-0800 (Pacific Standard Time)", time.Date(2006, 1, 2, 15, 4, 5, 0, pst)},
+		{"Mon Jan 02 2006 15:04:05 GMT+0000 (Coordinated Universal Time (UTC))", time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"Mon Jan 02 2006 15:04:05 GMT+0900", time.Date(2006, 1, 2, 15, 4, 5, 0, jst)},
+	})
+
+	testPredefined(t, flextime.NewFlextime(flextime.JavaDate), []predefinedTestCase{
+		{"Mon Jan 02 15:04:05 PST 2006", time.Date(2006, 1, 2, 15, 4, 5, 0, pst)},
+		{"Mon Jan 02 15:04:05 GMT+09:00 2006", time.Date(2006, 1, 2, 15, 4, 5, 0, jst)},
+	})
+
+	testPredefined(t, flextime.NewFlextime(flextime.PythonDateTime), []predefinedTestCase{
+		{"2006-01-02", time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2006-01-02 15:04:05", time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"2006-01-02 15:04:05.123456+09:00", time.Date(2006, 1, 2, 15, 4, 5, 123456000, jst)},
+		{"2006-01-02T15:04:05.123456", time.Date(2006, 1, 2, 15, 4, 5, 123456000, time.UTC)},
+		{"2006-01-02T15:04:05+09:00", time.Date(2006, 1, 2, 15, 4, 5, 0, jst)},
+	})
+
+	testPredefined(t, flextime.DotNetJSONDate, []predefinedTestCase{
+		{"/Date(1650000000000)/", time.UnixMilli(1650000000000)},
+		{"/Date(1650000000000+0900)/", time.UnixMilli(1650000000000)},
+		{`\/Date(-1650000000000-0800)\/`, time.UnixMilli(-1650000000000)},
+	})
+	parsed, err := flextime.DotNetJSONDate.Parse("/Date(1650000000000+0900)/")
+	require.NoError(t, err)
+	_, offset := parsed.Zone()
+	require.Equal(t, 9*60*60, offset)
+	for _, invalid := range []string{
+		"Date(1650000000000)",
+		"/Date()/",
+		"/Date(1650000000000+09)/",
+		"/Date(1650000000000+0900a)/",
+		"/Date(0+9999)/",
+		"/Date(0+2400)/",
+		"/Date(0-0060)/",
+	} {
+		_, err := flextime.DotNetJSONDate.Parse(invalid)
+		var parseErr *time.ParseError
+		require.ErrorAs(t, err, &parseErr, invalid)
+	}
+
+	testPredefined(t, flextime.NewFlextime(flextime.RubyTime), []predefinedTestCase{
+		{"2006-01-02 15:04:05 +0900", time.Date(2006, 1, 2, 15, 4, 5, 0, jst)},
+		{"2006-01-02 15:04:05 UTC", time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)},
+	})
+
+	testPredefined(t, combinedStringParser{flextime.RuntimeDateParser}, []predefinedTestCase{
+		{"Mon Jan 02 2006 15:04:05 GMT+0900 (Japan Standard Time)", time.Date(2006, 1, 2, 15, 4, 5, 0, jst)},
+		{"Mon Jan 02 15:04:05 GMT+09:00 2006", time.Date(2006, 1, 2, 15, 4, 5, 0, jst)},
+		{"2006-01-02T15:04:05.123456+09:00", time.Date(2006, 1, 2, 15, 4, 5, 123456000, jst)},
+		{"/Date(1650000000000+0900)/", time.UnixMilli(1650000000000)},
+		{"2006-01-02 15:04:05 +0900", time.Date(2006, 1, 2, 15, 4, 5, 0, jst)},
+	})
+}
+
+func TestTrailingComment(t *testing.T) {
+	p := flextime.NewFlextime(flextime.RFC5322Date, flextime.WithTrailingComment())
+	testPredefined(t, p, []predefinedTestCase{
+		{"Fri, 21 Nov 1997 09:55:06 +0200 (CEST)", time.Date(1997, 11, 21, 9, 55, 6, 0, time.FixedZone("", 2*60*60))},
+		{"Fri, 21 Nov 1997 09:55:06 +0200  (CEST)  ", time.Date(1997, 11, 21, 9, 55, 6, 0, time.FixedZone("", 2*60*60))},
+	})
+
+	// unbalanced parentheses are not a comment.
+	_, err := p.Parse("Fri, 21 Nov 1997 09:55:06 +0200 CEST)")
+	require.Error(t, err)
+
+	_, err = p.Parse("Fri, 21 Nov 1997 09:55:66 +0200 (CEST)")
+	var parseErr *time.ParseError
+	require.ErrorAs(t, err, &parseErr)
+	require.Equal(t, "Fri, 21 Nov 1997 09:55:66 +0200 (CEST)", parseErr.Value)
+}
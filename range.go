@@ -0,0 +1,179 @@
+package flextime
+
+import (
+	"strings"
+	"time"
+)
+
+// precision is the smallest unit of time a layout has.
+type precision int
+
+const (
+	precisionYear precision = iota + 1
+	precisionMonth
+	precisionDay
+	precisionHour
+	precisionMinute
+	precisionSecond
+	precisionFrac
+)
+
+func layoutPrecision(chunks []layoutChunk) precision {
+	var p precision
+	for _, c := range chunks {
+		var cp precision
+		switch {
+		case c.isFrac():
+			cp = precisionFrac
+		case c.kind == stdChunk:
+			switch c.value {
+			case "2006", "06":
+				cp = precisionYear
+			case "1", "01", "Jan", "January":
+				cp = precisionMonth
+			case "2", "02", "_2", "002", "__2":
+				cp = precisionDay
+			case "15", "3", "03":
+				cp = precisionHour
+			case "4", "04":
+				cp = precisionMinute
+			case "5", "05":
+				cp = precisionSecond
+			}
+		case c.kind == extChunk:
+			switch c.value {
+			case "E", "EE", "EEEE":
+				cp = precisionYear
+			case "Do", weekDateExtended, weekDateBasic:
+				cp = precisionDay
+			}
+			if isExpandedYearToken(c.value) {
+				cp = precisionYear
+			}
+		}
+		if cp > p {
+			p = cp
+		}
+	}
+	return p
+}
+
+// writtenFracDigits returns the number of digits of fractional seconds written in value, which time.Parse parsed in layout.
+// Fractional seconds may be of variable width, e.g. .999, or follow seconds which have no fractional seconds in layout,
+// so layout is tried with fractional seconds of each fixed width.
+func writtenFracDigits(layout, value string) int {
+	chunks := splitLayout(layout)
+	for i, c := range chunks {
+		var before, after string
+		sep := "."
+		switch {
+		case c.isFrac():
+			before, after, sep = joinChunks(chunks[:i]), joinChunks(chunks[i+1:]), c.value[:1]
+		case c.kind == stdChunk && (c.value == "5" || c.value == "05") && !nextIsFrac(chunks[i+1:]):
+			before, after = joinChunks(chunks[:i+1]), joinChunks(chunks[i+1:])
+		default:
+			continue
+		}
+		for digits := 1; digits <= 9; digits++ {
+			if _, err := time.Parse(before+sep+strings.Repeat("0", digits)+after, value); err == nil {
+				return digits
+			}
+		}
+		return 0
+	}
+	return 0
+}
+
+func joinChunks(chunks []layoutChunk) string {
+	var b strings.Builder
+	for _, c := range chunks {
+		b.WriteString(c.String())
+	}
+	return b.String()
+}
+
+// periodEnd returns the end of the period which starts at t and is as long as the precision of layout,
+// e.g. the next month of t for 2006-01.
+// If value had fracDigits digits of fractional seconds, the period is as long as the last of them, e.g. 1ms for .500.
+func periodEnd(layout *compiledLayout, t time.Time, fracDigits int) time.Time {
+	p := layoutPrecision(layout.chunks)
+	if fracDigits > 0 && p >= precisionSecond {
+		unit := time.Duration(1)
+		for i := fracDigits; i < 9; i++ {
+			unit *= 10
+		}
+		return t.Add(unit)
+	}
+	switch p {
+	case precisionYear:
+		return t.AddDate(1, 0, 0)
+	case precisionMonth:
+		return t.AddDate(0, 1, 0)
+	case precisionDay:
+		return t.AddDate(0, 0, 1)
+	case precisionHour:
+		return t.Add(time.Hour)
+	case precisionMinute:
+		return t.Add(time.Minute)
+	}
+	return t.Add(time.Second)
+}
+
+// rangeSeparators separate start and end of explicit ranges.
+var rangeSeparators = []string{" to ", ".."}
+
+// ParseRange parses value into the half-open range [Start, End) which value covers.
+//
+// A partial value is expanded by the precision of the layout it matched,
+// e.g. 2022-03 of RFC3339Partial is [2022-03-01, 2022-04-01) and 2022-03-01T15 is [15:00, 16:00).
+// Explicit ranges, start..end or start to end, are [start, end), e.g. 2022-01-01..2022-02-01 is January.
+func (f *Flextime) ParseRange(value string) (Interval, error) {
+	return f.parseRange(value, func(layout, value string) (time.Time, error) { return time.Parse(layout, value) })
+}
+
+// ParseRangeInLocation is same as ParseRange but parses values as ParseInLocation does.
+func (f *Flextime) ParseRangeInLocation(value string, loc *time.Location) (Interval, error) {
+	return f.parseRange(value, f.inLocation(loc))
+}
+
+func (f *Flextime) parseRange(value string, parser func(layout, value string) (time.Time, error)) (Interval, error) {
+	for _, sep := range rangeSeparators {
+		first, second, ok := strings.Cut(value, sep)
+		if !ok {
+			continue
+		}
+		start, err := f.parse(first, parser)
+		if err != nil {
+			return Interval{}, err
+		}
+		end, err := f.parse(second, parser)
+		if err != nil {
+			return Interval{}, err
+		}
+		if end.Before(start) {
+			return Interval{}, &time.ParseError{
+				Layout:     f.layouts.Layout()[0],
+				Value:      value,
+				LayoutElem: sep,
+				ValueElem:  second,
+				Message:    ": end of range is before start",
+			}
+		}
+		return Interval{Start: start, End: end}, nil
+	}
+
+	// The layout and the value time.Parse parsed last are those of the match, since layouts are tried in order.
+	var goLayout, goValue string
+	t, matched, err := f.parseMatched(value, func(layout, value string) (time.Time, error) {
+		t, err := parser(layout, value)
+		if err == nil {
+			goLayout, goValue = layout, value
+		}
+		return t, err
+	})
+	if err != nil {
+		return Interval{}, err
+	}
+	end := periodEnd(matched.layout, t, writtenFracDigits(goLayout, goValue))
+	return Interval{Start: f.opts.finish(t), End: f.opts.finish(end)}, nil
+}
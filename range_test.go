@@ -0,0 +1,69 @@
+package flextime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRange(t *testing.T) {
+	f := flextime.NewFlextime(flextime.RFC3339Partial)
+
+	for _, testCase := range []struct {
+		input string
+		start time.Time
+		end   time.Time
+	}{
+		{"2022", time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2022-03", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"2022-12", time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2022-02-28", time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"2022-01-02T15", time.Date(2022, 1, 2, 15, 0, 0, 0, time.UTC), time.Date(2022, 1, 2, 16, 0, 0, 0, time.UTC)},
+		{"2022-01-02T15:04", time.Date(2022, 1, 2, 15, 4, 0, 0, time.UTC), time.Date(2022, 1, 2, 15, 5, 0, 0, time.UTC)},
+		{"2022-01-02T15:04:05", time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC), time.Date(2022, 1, 2, 15, 4, 6, 0, time.UTC)},
+		{"2022-01-02T15:04:05.12", time.Date(2022, 1, 2, 15, 4, 5, 120000000, time.UTC), time.Date(2022, 1, 2, 15, 4, 5, 130000000, time.UTC)},
+		{"2022-01-02T15:04:05.123456789", time.Date(2022, 1, 2, 15, 4, 5, 123456789, time.UTC), time.Date(2022, 1, 2, 15, 4, 5, 123456790, time.UTC)},
+		// trailing zeros are written digits too.
+		{"2022-01-02T15:04:05.500Z", time.Date(2022, 1, 2, 15, 4, 5, 500000000, time.UTC), time.Date(2022, 1, 2, 15, 4, 5, 501000000, time.UTC)},
+		{"2022-01-02T15:04:05.000Z", time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC), time.Date(2022, 1, 2, 15, 4, 5, 1000000, time.UTC)},
+		{"2022-01-02T15:04:05,5", time.Date(2022, 1, 2, 15, 4, 5, 500000000, time.UTC), time.Date(2022, 1, 2, 15, 4, 5, 600000000, time.UTC)},
+		{"2022-01-01..2022-02-01", time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"2022-01-01 to 2022-02-01", time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"2022..2022-01-02T15:04", time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 2, 15, 4, 0, 0, time.UTC)},
+	} {
+		r, err := f.ParseRange(testCase.input)
+		require.NoError(t, err, testCase.input)
+		assert.Equal(t, testCase.start, r.Start, testCase.input)
+		assert.Equal(t, testCase.end, r.End, testCase.input)
+	}
+
+	// days are calendar days of the location.
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	r, err := f.ParseRangeInLocation("2022-03-13", ny)
+	require.NoError(t, err)
+	assert.Equal(t, 23*time.Hour, r.End.Sub(r.Start))
+
+	// fixed digits of fractional seconds decide the precision.
+	fixed, err := flextime.NewLayoutSet(`YYYY-MM-DD HH:mm:ss.SSS`)
+	require.NoError(t, err)
+	r, err = flextime.NewFlextime(fixed).ParseRange("2022-10-20 07:22:46.120")
+	require.NoError(t, err)
+	assert.Equal(t, time.Millisecond, r.End.Sub(r.Start))
+
+	// fractional seconds which the layout does not have, but time.Parse accepts after seconds.
+	seconds, err := flextime.NewLayoutSet(`YYYY-MM-DD HH:mm:ss`)
+	require.NoError(t, err)
+	r, err = flextime.NewFlextime(seconds).ParseRange("2022-10-20 07:22:46.50")
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Millisecond, r.End.Sub(r.Start))
+
+	for _, invalid := range []string{"2022-13", "2022-02-01..2022-01-01", "2022-01-01..", "foo to 2022"} {
+		_, err := f.ParseRange(invalid)
+		var parseErr *time.ParseError
+		assert.ErrorAs(t, err, &parseErr, invalid)
+	}
+}
@@ -0,0 +1,102 @@
+package flextime
+
+import "time"
+
+// ReferenceTime is a policy to fill fields absent from layouts, e.g. the year of Jan  2 15:04:05
+// or the date of 15:04, from a reference clock.
+//
+// Fields coarser than the coarsest one of the matched layout are taken from Now.
+// If the value would be later than Now by more than MaxFuture, it is rolled back by one period of the absent field,
+// so that a December entry read in January belongs to the previous year and 23:59 read at 00:01 belongs to yesterday.
+type ReferenceTime struct {
+	// Now returns the reference time. time.Now is used if nil.
+	Now func() time.Time
+	// MaxFuture is a tolerance for values later than Now, e.g. by clock skew.
+	MaxFuture time.Duration
+	// yearOnly makes only the year filled, and never the year after Now. It is set by WithYearInference.
+	yearOnly bool
+}
+
+// WithReferenceTime makes Flextime fill the fields absent from layouts according to r.
+// It replaces WithYearInference, and vice versa: the one passed later is used.
+func WithReferenceTime(r ReferenceTime) Option {
+	return optionFunc(func(o *options) {
+		o.referenceTime = &r
+	})
+}
+
+func (r ReferenceTime) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+func (r ReferenceTime) fill(layout *compiledLayout, t time.Time) time.Time {
+	coarsest, first := layout.coarsest, 0
+	if r.yearOnly {
+		if layout.hasYear {
+			return t
+		}
+		// Only the year is filled, starting from the one of Now.
+		coarsest, first = precisionMonth, 1
+	}
+	return fillAbsent(t, coarsest, r.now().In(t.Location()), r.MaxFuture, first)
+}
+
+// layoutCoarsest returns the coarsest precision among chunks. It is 0 if chunks have no date or time.
+func layoutCoarsest(chunks []layoutChunk) precision {
+	var coarsest precision
+	for _, c := range chunks {
+		p := layoutPrecision([]layoutChunk{c})
+		if c.kind == extChunk && (c.value == weekDateExtended || c.value == weekDateBasic) {
+			// A week date has the year too.
+			p = precisionYear
+		}
+		if p != 0 && (coarsest == 0 || p < coarsest) {
+			coarsest = p
+		}
+	}
+	return coarsest
+}
+
+// fillAbsent fills fields of t coarser than coarsest from now.
+// Candidates are tried from the next period of now backwards, skipping first of them,
+// and the first one not later than now by more than maxFuture is returned.
+func fillAbsent(t time.Time, coarsest precision, now time.Time, maxFuture time.Duration, first int) time.Time {
+	var tries int
+	var candidate func(i int) (time.Time, bool)
+	switch coarsest {
+	case precisionMonth:
+		// 8 years surely have February 29, except around 1900 or 2100.
+		tries = 9
+		candidate = func(i int) (time.Time, bool) {
+			year := now.Year() + 1 - i
+			return withYear(t, year), validDate(year, t.Month(), t.Day())
+		}
+	case precisionDay:
+		tries = 14
+		candidate = func(i int) (time.Time, bool) {
+			month := time.Date(now.Year(), now.Month()+1-time.Month(i), 1, 0, 0, 0, 0, time.UTC)
+			return time.Date(
+				month.Year(), month.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location(),
+			), validDate(month.Year(), month.Month(), t.Day())
+		}
+	case precisionHour, precisionMinute, precisionSecond, precisionFrac:
+		tries = 3
+		candidate = func(i int) (time.Time, bool) {
+			y, m, d := now.AddDate(0, 0, 1-i).Date()
+			return time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()), true
+		}
+	default:
+		return t
+	}
+
+	limit := now.Add(maxFuture)
+	for i := first; i < tries; i++ {
+		if c, ok := candidate(i); ok && !c.After(limit) {
+			return c
+		}
+	}
+	return t
+}
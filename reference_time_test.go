@@ -0,0 +1,64 @@
+package flextime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReferenceTime(t *testing.T) {
+	now := time.Date(2023, 1, 2, 0, 1, 0, 0, time.UTC)
+	ref := flextime.WithReferenceTime(flextime.ReferenceTime{
+		Now:       func() time.Time { return now },
+		MaxFuture: time.Minute,
+	})
+
+	timeOnly, err := flextime.NewLayoutSet(`HH:mm[:ss]`)
+	require.NoError(t, err)
+	dayOnly, err := flextime.NewExtendedLayoutSet(`Do HH:mm`)
+	require.NoError(t, err)
+
+	for _, testCase := range []struct {
+		layouts  *flextime.LayoutSet
+		input    string
+		expected time.Time
+	}{
+		// year
+		{flextime.RFC3164Syslog, "Jan  2 00:00:00", time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{flextime.RFC3164Syslog, "Dec 31 23:59:59", time.Date(2022, 12, 31, 23, 59, 59, 0, time.UTC)},
+		{flextime.RFC3164Syslog, "Jan  2 00:02:00", time.Date(2023, 1, 2, 0, 2, 0, 0, time.UTC)},
+		{flextime.RFC3164Syslog, "Jan  2 00:03:00", time.Date(2022, 1, 2, 0, 3, 0, 0, time.UTC)},
+		{flextime.RFC3164Syslog, "Feb 29 00:00:00", time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// date
+		{timeOnly, "00:00", time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{timeOnly, "23:59:30", time.Date(2023, 1, 1, 23, 59, 30, 0, time.UTC)},
+		{timeOnly, "00:02", time.Date(2023, 1, 2, 0, 2, 0, 0, time.UTC)},
+		// year and month
+		{dayOnly, "1st 12:00", time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{dayOnly, "31st 12:00", time.Date(2022, 12, 31, 12, 0, 0, 0, time.UTC)},
+		// values with all fields are left as is.
+		{flextime.RFC3339, "2030-01-02T15:04:05Z", time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)},
+	} {
+		parsed, err := flextime.NewFlextime(testCase.layouts, ref).Parse(testCase.input)
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, testCase.expected, parsed, testCase.input)
+	}
+
+	// fields are filled in the location of the value.
+	parsed, err := flextime.NewFlextime(timeOnly, ref).ParseInLocation("08:00", jst)
+	require.NoError(t, err)
+	require.True(t, time.Date(2023, 1, 2, 8, 0, 0, 0, jst).Equal(parsed), "actual = %s", parsed)
+
+	// rolls over into the next year within MaxFuture.
+	parsed, err = flextime.NewFlextime(
+		flextime.RFC3164Syslog,
+		flextime.WithReferenceTime(flextime.ReferenceTime{
+			Now:       func() time.Time { return time.Date(2022, 12, 31, 23, 59, 0, 0, time.UTC) },
+			MaxFuture: time.Hour,
+		}),
+	).Parse("Jan  1 00:10:00")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, 1, 1, 0, 10, 0, 0, time.UTC), parsed)
+}
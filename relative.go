@@ -0,0 +1,169 @@
+package flextime
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Relative parses relative dates in English anchored to a clock. It implements StringParser.
+// Accepted forms are, case-insensitively:
+//
+//   - now
+//   - today, yesterday and tomorrow, which are the start of the day
+//   - N units ago and in N units, where N is a number, a or an, and units are second, minute, hour, day, week, month or year
+//   - last and next followed by a weekday, which are the start of the day
+type Relative struct {
+	// Now returns the reference time. time.Now is used if nil.
+	Now func() time.Time
+	// Location is where days start. The location of Now is used if nil.
+	// ParseInLocation overrides it.
+	Location *time.Location
+}
+
+const relativeLayout = "relative date"
+
+func (r Relative) Parse(value string) (time.Time, error) {
+	loc := r.Location
+	if loc == nil {
+		loc = r.now().Location()
+	}
+	return r.ParseInLocation(value, loc)
+}
+
+func (r Relative) ParseInLocation(value string, loc *time.Location) (time.Time, error) {
+	parseErr := func(msg string) error {
+		return &time.ParseError{
+			Layout:     relativeLayout,
+			Value:      value,
+			LayoutElem: relativeLayout,
+			ValueElem:  value,
+			Message:    ": " + msg,
+		}
+	}
+
+	now := r.now().In(loc)
+	fields := strings.Fields(strings.ToLower(value))
+
+	switch len(fields) {
+	case 1:
+		switch fields[0] {
+		case "now":
+			return now, nil
+		case "today":
+			return startOfDay(now), nil
+		case "yesterday":
+			return startOfDay(now).AddDate(0, 0, -1), nil
+		case "tomorrow":
+			return startOfDay(now).AddDate(0, 0, 1), nil
+		}
+	case 2:
+		weekday, ok := lookupWeekday(fields[1])
+		if !ok {
+			break
+		}
+		today := startOfDay(now)
+		switch fields[0] {
+		case "last":
+			diff := (int(now.Weekday()) - int(weekday) + 6) % 7
+			return today.AddDate(0, 0, -diff-1), nil
+		case "next":
+			diff := (int(weekday) - int(now.Weekday()) + 6) % 7
+			return today.AddDate(0, 0, diff+1), nil
+		}
+	case 3:
+		var t time.Time
+		var ok, overflow bool
+		if fields[0] == "in" {
+			t, ok, overflow = addRelative(now, fields[1], fields[2], 1)
+		} else if fields[2] == "ago" {
+			t, ok, overflow = addRelative(now, fields[0], fields[1], -1)
+		}
+		if overflow {
+			return time.Time{}, parseErr("relative date out of range " + strconv.Quote(value))
+		}
+		if ok {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, parseErr("unknown relative date " + strconv.Quote(value))
+}
+
+func (r Relative) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func lookupWeekday(name string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), name) {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// maxRelativeDays is the largest number of days added by a relative date.
+// Times shifted by it stay within the range of seconds of time.Time, int64, from any practical time.
+const maxRelativeDays = math.MaxInt64 / 2 / (24 * 60 * 60)
+
+// addRelative adds amount of unit, e.g. "3" and "days", multiplied by sign to t.
+// overflow is true if amount is too large for unit.
+func addRelative(t time.Time, amount, unit string, sign int) (added time.Time, ok bool, overflow bool) {
+	var n int
+	switch amount {
+	case "a", "an":
+		n = 1
+	default:
+		var err error
+		n, err = strconv.Atoi(amount)
+		if errors.Is(err, strconv.ErrRange) && n > 0 {
+			// too large for any unit.
+			err = nil
+		}
+		if err != nil || n < 0 {
+			return time.Time{}, false, false
+		}
+	}
+
+	addDuration := func(d time.Duration) (time.Time, bool, bool) {
+		if int64(n) > math.MaxInt64/int64(d) {
+			return time.Time{}, false, true
+		}
+		return t.Add(time.Duration(sign*n) * d), true, false
+	}
+	// maxDays is the most days a unit has.
+	addDate := func(maxDays, years, months, days int) (time.Time, bool, bool) {
+		if int64(n) > maxRelativeDays/int64(maxDays) {
+			return time.Time{}, false, true
+		}
+		return t.AddDate(sign*n*years, sign*n*months, sign*n*days), true, false
+	}
+
+	switch strings.TrimSuffix(unit, "s") {
+	case "second":
+		return addDuration(time.Second)
+	case "minute":
+		return addDuration(time.Minute)
+	case "hour":
+		return addDuration(time.Hour)
+	case "day":
+		return addDate(1, 0, 0, 1)
+	case "week":
+		return addDate(7, 0, 0, 7)
+	case "month":
+		return addDate(31, 0, 1, 0)
+	case "year":
+		return addDate(366, 1, 0, 0)
+	}
+	return time.Time{}, false, false
+}
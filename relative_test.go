@@ -0,0 +1,86 @@
+package flextime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelative(t *testing.T) {
+	// Wednesday
+	now := time.Date(2022, 1, 5, 15, 4, 5, 0, jst)
+	r := flextime.Relative{Now: func() time.Time { return now }}
+
+	for _, testCase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"now", now},
+		{"Now", now},
+		{"today", time.Date(2022, 1, 5, 0, 0, 0, 0, jst)},
+		{"yesterday", time.Date(2022, 1, 4, 0, 0, 0, 0, jst)},
+		{"tomorrow", time.Date(2022, 1, 6, 0, 0, 0, 0, jst)},
+		{"3 days ago", time.Date(2022, 1, 2, 15, 4, 5, 0, jst)},
+		{"in 2 hours", time.Date(2022, 1, 5, 17, 4, 5, 0, jst)},
+		{"an hour ago", time.Date(2022, 1, 5, 14, 4, 5, 0, jst)},
+		{"in  1 week", time.Date(2022, 1, 12, 15, 4, 5, 0, jst)},
+		{"2 months ago", time.Date(2021, 11, 5, 15, 4, 5, 0, jst)},
+		{"last monday", time.Date(2022, 1, 3, 0, 0, 0, 0, jst)},
+		{"last wednesday", time.Date(2021, 12, 29, 0, 0, 0, 0, jst)},
+		{"next Wednesday", time.Date(2022, 1, 12, 0, 0, 0, 0, jst)},
+		{"next sunday", time.Date(2022, 1, 9, 0, 0, 0, 0, jst)},
+	} {
+		parsed, err := r.Parse(testCase.input)
+		require.NoError(t, err, testCase.input)
+		require.Equal(t, testCase.expected, parsed, testCase.input)
+	}
+
+	// days start in the location.
+	parsed, err := r.ParseInLocation("today", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2022, 1, 5, 0, 0, 0, 0, time.UTC), parsed)
+	parsed, err = flextime.Relative{Now: func() time.Time { return now }, Location: time.UTC}.Parse("today")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2022, 1, 5, 0, 0, 0, 0, time.UTC), parsed)
+
+	for _, invalid := range []string{
+		"",
+		"later",
+		"3 days",
+		"in -1 days",
+		"last day",
+		"in 3 fortnights",
+		"in 99999999999999999999 fortnights",
+	} {
+		_, err := r.Parse(invalid)
+		var parseErr *time.ParseError
+		require.ErrorAs(t, err, &parseErr, invalid)
+		require.Contains(t, parseErr.Message, "unknown relative date", invalid)
+	}
+
+	// amounts too large for units are errors, not wrapped around.
+	for _, overflow := range []string{
+		"in 99999999999 hours",
+		"9223372036854775807 seconds ago",
+		"in 99999999999999999999 seconds",
+		"in 9223372036854775807 days",
+		"in 999999999999999 years",
+	} {
+		_, err := r.Parse(overflow)
+		var parseErr *time.ParseError
+		require.ErrorAs(t, err, &parseErr, overflow)
+		require.Contains(t, parseErr.Message, "out of range", overflow)
+	}
+	parsed, err = r.Parse("in 2562047 hours")
+	require.NoError(t, err)
+	require.True(t, parsed.After(now))
+
+	c := flextime.NewCombinedParsers([]flextime.StringParser{flextime.NewFlextime(flextime.RFC3339), r}, time.UnixMilli)
+	for _, input := range []string{"2022-01-05T15:04:05+09:00", "now"} {
+		parsed, err := c.Parse(input)
+		require.NoError(t, err)
+		require.True(t, now.Equal(parsed), "actual = %s", parsed)
+	}
+}
@@ -0,0 +1,598 @@
+package flextime
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ngicks/flextime/locale"
+)
+
+// scanner walks a value along chunks of a layout, and rewrites both
+// into the form time.Parse understands:
+// localized names are translated into English and extension tokens are replaced with Go's std tokens.
+type scanner struct {
+	opts   *options
+	layout string
+	value  string
+	rest   string
+	// takeYearDay makes day of year taken into fields instead of passed to time.Parse,
+	// which checks it against month and day but with an untyped error.
+	takeYearDay bool
+	// next is chunks after the one being scanned, for extension tokens whose width depends on them.
+	next []layoutChunk
+
+	outLayout strings.Builder
+	outValue  strings.Builder
+	segments  []segment
+
+	fields scannedFields
+}
+
+// scannedFields holds values of extension tokens which can not be written back in place.
+// They are resolved after all chunks are scanned.
+type scannedFields struct {
+	era      *Era
+	eraYear  int
+	location *time.Location
+	// yearShift is added to the parsed year, which is a proxy of an expanded year.
+	yearShift int
+	// endOfDay is true if the hour is 24.
+	endOfDay bool
+	// leapSecond is true if the second is 60.
+	leapSecond bool
+	// weekday and yearDay are checked against the date by WithStrictDate.
+	weekday *time.Weekday
+	yearDay int
+}
+
+// segment maps a range of the rewritten value to a range of the original value.
+type segment struct {
+	outStart, outLen int
+	inStart, inLen   int
+}
+
+func newScanner(opts *options, layout, value string) *scanner {
+	return &scanner{
+		opts:   opts,
+		layout: layout,
+		value:  value,
+		rest:   value,
+	}
+}
+
+func (s *scanner) scan(chunks []layoutChunk) error {
+	s.takeYearDay = s.opts.strictDate && hasMonthAndDayChunk(chunks)
+	for i, c := range chunks {
+		var ok bool
+		switch c.kind {
+		case literalChunk:
+			ok = s.literal(c.value)
+		case stdChunk:
+			ok = s.std(c.value, chunks[i+1:])
+		case extChunk:
+			var handler extTokenHandler
+			s.next = chunks[i+1:]
+			handler, ok = lookupExtToken(c.value)
+			ok = ok && handler.parse(s)
+		}
+		if !ok {
+			return &time.ParseError{
+				Layout:     s.layout,
+				Value:      s.value,
+				LayoutElem: c.String(),
+				ValueElem:  s.rest,
+			}
+		}
+	}
+	if len(s.rest) > 0 {
+		return &time.ParseError{
+			Layout:    s.layout,
+			Value:     s.value,
+			ValueElem: s.rest,
+			Message:   ": extra text: " + strconv.Quote(s.rest),
+		}
+	}
+	if msg := s.finish(); msg != "" {
+		return &time.ParseError{
+			Layout:  s.layout,
+			Value:   s.value,
+			Message: ": " + msg,
+		}
+	}
+	return nil
+}
+
+// finish appends fields which are resolved only after all chunks are scanned to the output.
+// It returns non empty message if fields are inconsistent.
+func (s *scanner) finish() string {
+	if s.fields.eraYear > 0 {
+		if s.fields.era == nil {
+			return "era year without era"
+		}
+		s.emit(" 2006", fmt.Sprintf(" %04d", s.fields.era.Year+s.fields.eraYear-1), 0)
+	}
+	return ""
+}
+
+// adjust applies fields which time.Parse can not handle to t parsed from the output.
+// It returns non empty message if t is inconsistent with them.
+func (s *scanner) adjust(t time.Time) (time.Time, string) {
+	if era := s.fields.era; era != nil && !s.opts.erasOrDefault().contains(*era, t) {
+		return time.Time{}, "date is out of era " + era.Name
+	}
+	if s.fields.yearShift != 0 {
+		t = t.AddDate(s.fields.yearShift, 0, 0)
+	}
+	if s.fields.endOfDay {
+		if !isMidnight(t) {
+			return time.Time{}, "hour 24 must be followed by zero minutes and seconds"
+		}
+		t = t.AddDate(0, 0, 1)
+	}
+	if s.fields.leapSecond {
+		t = resolveLeapSecond(t, s.opts.leapSecond)
+	}
+	return t, ""
+}
+
+// emit writes layout and value to the output, consuming n bytes of the rest of the input.
+func (s *scanner) emit(layout, value string, n int) {
+	s.outLayout.WriteString(layout)
+	s.segments = append(s.segments, segment{
+		outStart: s.outValue.Len(),
+		outLen:   len(value),
+		inStart:  len(s.value) - len(s.rest),
+		inLen:    n,
+	})
+	s.outValue.WriteString(value)
+	s.rest = s.rest[n:]
+}
+
+// pass consumes n bytes of the input, writing them to the output as is.
+func (s *scanner) pass(layout string, n int) {
+	s.emit(layout, s.rest[:n], n)
+}
+
+func (s *scanner) literal(lit string) bool {
+	n, matched, ok := skipLen(s.rest, lit, s.opts.caseInsensitive)
+	if !ok {
+		return false
+	}
+	s.emit(lit, matched, n)
+	return true
+}
+
+func (s *scanner) std(std string, next []layoutChunk) bool {
+	l := s.opts.localeOrDefault()
+	switch std {
+	case "January", "Jan":
+		lookup, name := l.LookupMonth, locale.EN.Month
+		if std == "Jan" {
+			lookup, name = l.LookupShortMonth, locale.EN.ShortMonth
+		}
+		m, n, ok := lookup(s.rest)
+		if ok {
+			s.emit(std, name(m), n)
+		}
+		return ok
+	case "Monday", "Mon":
+		lookup, name := l.LookupWeekday, locale.EN.Weekday
+		if std == "Mon" {
+			lookup, name = l.LookupShortWeekday, locale.EN.ShortWeekday
+		}
+		w, n, ok := lookup(s.rest)
+		if ok {
+			s.emit(std, name(w), n)
+			if s.opts.strictDate {
+				s.fields.weekday = &w
+			}
+		}
+		return ok
+	case "PM", "pm":
+		am, pm := l.AM, l.PM
+		if std == "pm" {
+			am, pm = strings.ToLower(am), strings.ToLower(pm)
+		}
+		hasPrefix := strings.HasPrefix
+		if s.opts.caseInsensitive {
+			hasPrefix = hasPrefixFold
+		}
+		switch {
+		case pm != "" && hasPrefix(s.rest, pm):
+			s.emit(std, pmMarker(std, true), len(pm))
+		case am != "" && hasPrefix(s.rest, am):
+			s.emit(std, pmMarker(std, false), len(am))
+		default:
+			return false
+		}
+		return true
+	}
+
+	if std == "15" && s.opts.endOfDay && strings.HasPrefix(s.rest, endOfDayHour) {
+		s.fields.endOfDay = true
+		s.emit(std, "00", len(endOfDayHour))
+		return true
+	}
+
+	if (std == "5" || std == "05") && s.opts.leapSecond != LeapSecondReject && strings.HasPrefix(s.rest, leapSecond) {
+		n := len(leapSecond)
+		if !nextIsFrac(next) {
+			n += fracLen(s.rest[n:])
+		}
+		s.fields.leapSecond = true
+		s.emit(std, "59"+s.rest[len(leapSecond):n], n)
+		return true
+	}
+
+	if (std == "002" || std == "__2") && s.takeYearDay {
+		n, ok := stdLen(std, s.rest)
+		if !ok {
+			return false
+		}
+		yday, _ := strconv.Atoi(strings.TrimLeft(s.rest[:n], " "))
+		if yday < 1 || yday > 366 {
+			return false
+		}
+		s.fields.yearDay = yday
+		s.emit("", "", n)
+		return true
+	}
+
+	if std == "MST" && s.opts.zoneResolver != nil {
+		return parseZoneAbbr(s)
+	}
+
+	if std == "06" && s.opts.twoDigitYear != nil {
+		n, ok := numLen(s.rest, 2, 2)
+		if !ok {
+			return false
+		}
+		yy, _ := strconv.Atoi(s.rest[:n])
+		year := s.opts.twoDigitYear.FullYear(yy)
+		if year < 0 || year > 9999 {
+			return false
+		}
+		s.emit("2006", fmt.Sprintf("%04d", year), n)
+		return true
+	}
+
+	if s.opts.caseInsensitive {
+		switch {
+		case std == "MST":
+			upper := upperASCII(s.rest)
+			n, ok := stdLen(std, upper)
+			if ok {
+				s.emit(std, upper[:n], n)
+			}
+			return ok
+		case std[0] == 'Z' && strings.HasPrefix(s.rest, "z"):
+			s.emit(std, "Z", 1)
+			return true
+		}
+	}
+
+	n, ok := stdLen(std, s.rest)
+	if !ok {
+		return false
+	}
+	if (std == "5" || std == "05") && !nextIsFrac(next) {
+		// The time package accepts fractional seconds after seconds even if the layout has no such part.
+		n += fracLen(s.rest[n:])
+	}
+	s.pass(std, n)
+	return true
+}
+
+// pmMarker returns the AM/PM marker in the case of std.
+func pmMarker(std string, pm bool) string {
+	marker := "AM"
+	if pm {
+		marker = "PM"
+	}
+	if std == "pm" {
+		return strings.ToLower(marker)
+	}
+	return marker
+}
+
+// remapError rewrites err, which time.Parse reported against the rewritten value,
+// into one against the original value.
+func (s *scanner) remapError(err error) error {
+	var parseErr *time.ParseError
+	if !errors.As(err, &parseErr) {
+		return err
+	}
+	remapped := *parseErr
+	remapped.Layout = s.layout
+	remapped.Value = s.value
+	out := s.outValue.String()
+	if strings.HasSuffix(out, parseErr.ValueElem) {
+		remapped.ValueElem = s.value[s.originalPos(len(out)-len(parseErr.ValueElem)):]
+	}
+	return &remapped
+}
+
+// originalPos converts a position in the rewritten value into one in the original value.
+func (s *scanner) originalPos(pos int) int {
+	for _, seg := range s.segments {
+		if seg.outStart <= pos && pos < seg.outStart+seg.outLen {
+			if seg.outLen == seg.inLen {
+				return seg.inStart + (pos - seg.outStart)
+			}
+			return seg.inStart
+		}
+	}
+	return len(s.value) - len(s.rest)
+}
+
+func nextIsFrac(next []layoutChunk) bool {
+	for _, c := range next {
+		if c.kind != literalChunk {
+			return c.isFrac()
+		}
+	}
+	return false
+}
+
+// skipLen is a port of skip of the time package.
+// It reports the length of the head of value which matches to prefix, and the matched part.
+// A space in prefix matches to one or more spaces.
+// If fold is true, letters are compared case-insensitively and the matched part is written in the case of prefix.
+func skipLen(value, prefix string, fold bool) (n int, matched string, ok bool) {
+	var b strings.Builder
+	for len(prefix) > 0 {
+		if prefix[0] == ' ' {
+			if n < len(value) && value[n] != ' ' {
+				return n, "", false
+			}
+			prefix = strings.TrimLeft(prefix, " ")
+			for n < len(value) && value[n] == ' ' {
+				b.WriteByte(' ')
+				n++
+			}
+			continue
+		}
+		p, pSize := utf8.DecodeRuneInString(prefix)
+		if strings.HasPrefix(value[n:], prefix[:pSize]) {
+			n += pSize
+		} else if v, vSize := utf8.DecodeRuneInString(value[n:]); fold &&
+			vSize > 0 && p != utf8.RuneError && equalFoldRune(p, v) {
+			n += vSize
+		} else {
+			return n, "", false
+		}
+		b.WriteString(prefix[:pSize])
+		prefix = prefix[pSize:]
+	}
+	return n, b.String(), true
+}
+
+func equalFoldRune(a, b rune) bool {
+	return strings.EqualFold(string(a), string(b))
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// upperASCII upper-cases ASCII letters of s, keeping its byte length.
+func upperASCII(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if 'a' <= c && c <= 'z' {
+			b[i] = c - 'a' + 'A'
+		}
+	}
+	return string(b)
+}
+
+// stdLen reports the length of the head of value which matches to std,
+// a numeric, zone or fractional seconds std token. It follows parse of the time package.
+func stdLen(std, value string) (int, bool) {
+	switch std {
+	case "1", "2", "3", "4", "5", "15":
+		return numLen(value, 1, 2)
+	case "01", "02", "03", "04", "05", "06":
+		return numLen(value, 2, 2)
+	case "_2":
+		n := 0
+		if len(value) > 0 && value[0] == ' ' {
+			n++
+		}
+		m, ok := numLen(value[n:], 1, 2)
+		return n + m, ok
+	case "002":
+		return numLen(value, 3, 3)
+	case "__2":
+		n := 0
+		for n < 2 && n < len(value) && value[n] == ' ' {
+			n++
+		}
+		m, ok := numLen(value[n:], 1, 3)
+		return n + m, ok
+	case "2006":
+		return numLen(value, 4, 4)
+	case "MST":
+		if strings.HasPrefix(value, "UTC") {
+			return 3, true
+		}
+		return zoneNameLen(value)
+	}
+	switch std[0] {
+	case 'Z', '-':
+		return offsetLen(std, value)
+	case '.', ',':
+		return fracStdLen(std, value)
+	}
+	return 0, false
+}
+
+// numLen reports the length of leading digits of value, up to max.
+func numLen(value string, min, max int) (int, bool) {
+	n := 0
+	for n < max && isDigit(value, n) {
+		n++
+	}
+	return n, n >= min
+}
+
+// zoneNameLen is a port of parseTimeZone of the time package.
+func zoneNameLen(value string) (int, bool) {
+	if len(value) < 3 {
+		return 0, false
+	}
+	if strings.HasPrefix(value, "ChST") || strings.HasPrefix(value, "MeST") {
+		return 4, true
+	}
+	if strings.HasPrefix(value, "GMT") {
+		return 3 + signedOffsetLen(value[3:]), true
+	}
+	if value[0] == '+' || value[0] == '-' {
+		n := signedOffsetLen(value)
+		return n, n > 0
+	}
+	var nUpper int
+	for nUpper = 0; nUpper < 6 && nUpper < len(value); nUpper++ {
+		if c := value[nUpper]; c < 'A' || 'Z' < c {
+			break
+		}
+	}
+	switch nUpper {
+	case 3:
+		return 3, true
+	case 4:
+		if value[3] == 'T' || value[:4] == "WITA" {
+			return 4, true
+		}
+	case 5:
+		if value[4] == 'T' {
+			return 5, true
+		}
+	}
+	return 0, false
+}
+
+// signedOffsetLen is a port of parseSignedOffset of the time package.
+// It returns 0 if value does not start with a signed hour offset in range of -23 through +23.
+func signedOffsetLen(value string) int {
+	if len(value) == 0 || (value[0] != '+' && value[0] != '-') {
+		return 0
+	}
+	n, x := 1, 0
+	for ; isDigit(value, n); n++ {
+		if x <= 23 {
+			x = x*10 + int(value[n]-'0')
+		}
+	}
+	if n == 1 || x > 23 {
+		return 0
+	}
+	return n
+}
+
+func offsetLen(std, value string) (int, bool) {
+	if std[0] == 'Z' && len(value) > 0 && value[0] == 'Z' {
+		return 1, true
+	}
+	if len(value) < len(std) || (value[0] != '+' && value[0] != '-') {
+		return 0, false
+	}
+	for i := 1; i < len(std); i++ {
+		if std[i] == ':' {
+			if value[i] != ':' {
+				return 0, false
+			}
+		} else if !isDigit(value, i) {
+			return 0, false
+		}
+	}
+	return len(std), true
+}
+
+func fracStdLen(std, value string) (int, bool) {
+	if std[1] == '0' {
+		if len(value) < len(std) || !commaOrPeriod(value[0]) {
+			return 0, false
+		}
+		for i := 1; i < len(std); i++ {
+			if !isDigit(value, i) {
+				return 0, false
+			}
+		}
+		return len(std), true
+	}
+	// .999 makes fractional seconds optional.
+	return fracLen(value), true
+}
+
+// fracLen reports the length of fractional seconds, including the leading period or comma, at the head of value.
+func fracLen(value string) int {
+	if len(value) < 2 || !commaOrPeriod(value[0]) || !isDigit(value, 1) {
+		return 0
+	}
+	n := 2
+	for isDigit(value, n) {
+		n++
+	}
+	return n
+}
+
+func commaOrPeriod(b byte) bool {
+	return b == '.' || b == ','
+}
+
+// formatChunks formats t along chunks, as time.Time.Format does for std tokens.
+func formatChunks(o *options, chunks []layoutChunk, t time.Time) string {
+	endOfDay := o.endOfDayFormat && isMidnight(t) && hasHourChunk(chunks)
+	if endOfDay {
+		t = t.AddDate(0, 0, -1)
+	}
+	var b strings.Builder
+	for _, c := range chunks {
+		switch c.kind {
+		case literalChunk:
+			b.WriteString(c.value)
+		case stdChunk:
+			if endOfDay && c.value == "15" {
+				b.WriteString(endOfDayHour)
+				continue
+			}
+			b.WriteString(formatStd(o, c.value, t))
+		case extChunk:
+			if handler, ok := lookupExtToken(c.value); ok {
+				b.WriteString(handler.format(o, t))
+			} else {
+				b.WriteString(c.String())
+			}
+		}
+	}
+	return b.String()
+}
+
+func formatStd(o *options, std string, t time.Time) string {
+	l := o.localeOrDefault()
+	switch std {
+	case "January":
+		return l.Month(t.Month())
+	case "Jan":
+		return l.ShortMonth(t.Month())
+	case "Monday":
+		return l.Weekday(t.Weekday())
+	case "Mon":
+		return l.ShortWeekday(t.Weekday())
+	case "PM", "pm":
+		marker := l.AM
+		if t.Hour() >= 12 {
+			marker = l.PM
+		}
+		if std == "pm" {
+			return strings.ToLower(marker)
+		}
+		return marker
+	}
+	return t.Format(std)
+}
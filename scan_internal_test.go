@@ -0,0 +1,76 @@
+package flextime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitLayout(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected []layoutChunk
+	}{
+		{
+			input: "2006-01-02T15:04:05.999999999Z07:00",
+			expected: []layoutChunk{
+				{kind: stdChunk, value: "2006"},
+				{kind: literalChunk, value: "-"},
+				{kind: stdChunk, value: "01"},
+				{kind: literalChunk, value: "-"},
+				{kind: stdChunk, value: "02"},
+				{kind: literalChunk, value: "T"},
+				{kind: stdChunk, value: "15"},
+				{kind: literalChunk, value: ":"},
+				{kind: stdChunk, value: "04"},
+				{kind: literalChunk, value: ":"},
+				{kind: stdChunk, value: "05"},
+				{kind: stdChunk, value: ".999999999"},
+				{kind: stdChunk, value: "Z07:00"},
+			},
+		},
+		{
+			input: "Monday Jan _2 Janet _2006 {{Do}} MST",
+			expected: []layoutChunk{
+				{kind: stdChunk, value: "Monday"},
+				{kind: literalChunk, value: " "},
+				{kind: stdChunk, value: "Jan"},
+				{kind: literalChunk, value: " "},
+				{kind: stdChunk, value: "_2"},
+				{kind: literalChunk, value: " Janet _"},
+				{kind: stdChunk, value: "2006"},
+				{kind: literalChunk, value: " "},
+				{kind: extChunk, value: "Do"},
+				{kind: literalChunk, value: " "},
+				{kind: stdChunk, value: "MST"},
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		assert.Equal(t, testCase.expected, splitLayout(testCase.input))
+	}
+}
+
+func TestScanMatchesTimeParse(t *testing.T) {
+	// With English locale, scanner must consume values as the time package does.
+	cases := []struct {
+		layout string
+		value  string
+	}{
+		{"2006-01-02T15:04:05Z07:00", "2022-10-20T23:16:22.168+09:00"},
+		{"2006-01-02 15:04:05.000 MST", "2022-10-20 23:16:22.168 JST"},
+		{"Mon Jan _2 15:04:05 2006", "Thu Oct  6 23:16:22 2022"},
+		{"02 Jan 06 15:04 -0700", "20 Oct 22 23:16 +0900"},
+		{"3:04PM", "11:16AM"},
+		{"__2 2006", " 15 2022"},
+	}
+
+	o := &options{}
+	for _, testCase := range cases {
+		s := newScanner(o, testCase.layout, testCase.value)
+		assert.NoError(t, s.scan(splitLayout(testCase.layout)), testCase.value)
+		assert.Equal(t, testCase.layout, s.outLayout.String())
+		assert.Equal(t, testCase.value, s.outValue.String())
+	}
+}
@@ -0,0 +1,80 @@
+package flextime
+
+import (
+	"fmt"
+	"time"
+)
+
+// WithStrictDate makes Flextime reject values whose weekday, or day of year along with month and day,
+// does not agree with the date with *DateMismatchError.
+// The time package parses weekdays without checking them, so Tue 2022-01-02 is accepted otherwise.
+//
+// Values without a year are checked only if the year is filled, e.g. by WithYearInference.
+func WithStrictDate() Option {
+	return optionFunc(func(o *options) {
+		o.strictDate = true
+	})
+}
+
+// DateMismatchError is reported by WithStrictDate for a value whose weekday or day of year does not agree with its date.
+type DateMismatchError struct {
+	Value string
+	// Date is the date the value has.
+	Date time.Time
+	// Weekday is the weekday the value has, if it does not agree with Date.
+	Weekday *time.Weekday
+	// YearDay is the day of year the value has, if it does not agree with Date. It is 0 otherwise.
+	YearDay int
+}
+
+func (e *DateMismatchError) Error() string {
+	date := e.Date.Format("2006-01-02")
+	if e.Weekday != nil {
+		return fmt.Sprintf(
+			"parsing time %q: weekday %s does not match %s (%s)", e.Value, *e.Weekday, date, e.Date.Weekday(),
+		)
+	}
+	return fmt.Sprintf(
+		"parsing time %q: day of year %d does not match %s (%d)", e.Value, e.YearDay, date, e.Date.YearDay(),
+	)
+}
+
+// checkDate checks t, parsed from value, against the weekday and day of year the value has.
+func (o *options) checkDate(value string, t time.Time, fields scannedFields) error {
+	if !o.strictDate {
+		return nil
+	}
+	// The date written in the value is the one before 24:00 or a leap second rolled over.
+	if fields.endOfDay {
+		t = t.AddDate(0, 0, -1)
+	}
+	if fields.leapSecond && o.leapSecond == LeapSecondRoll {
+		t = t.Add(-time.Second)
+	}
+	if fields.weekday != nil && *fields.weekday != t.Weekday() {
+		return &DateMismatchError{Value: value, Date: t, Weekday: fields.weekday}
+	}
+	if fields.yearDay > 0 && fields.yearDay != t.YearDay() {
+		return &DateMismatchError{Value: value, Date: t, YearDay: fields.yearDay}
+	}
+	return nil
+}
+
+// hasMonthAndDayChunk reports whether chunks has both month and day of month.
+func hasMonthAndDayChunk(chunks []layoutChunk) bool {
+	var month, day bool
+	for _, c := range chunks {
+		switch {
+		case c.kind == stdChunk:
+			switch c.value {
+			case "1", "01", "Jan", "January":
+				month = true
+			case "2", "02", "_2":
+				day = true
+			}
+		case c.kind == extChunk && c.value == "Do":
+			day = true
+		}
+	}
+	return month && day
+}
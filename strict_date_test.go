@@ -0,0 +1,78 @@
+package flextime_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/ngicks/flextime/locale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictDate(t *testing.T) {
+	weekday, err := flextime.NewLayoutSet(`w YYYY-MM-DD`)
+	require.NoError(t, err)
+	yearDay, err := flextime.NewLayoutSet(`YYYY-MM-DD ddd`)
+	require.NoError(t, err)
+	layouts := weekday.AddLayout(yearDay)
+
+	_, err = flextime.NewFlextime(layouts).Parse("Tue 2022-01-02")
+	assert.NoError(t, err)
+
+	f := flextime.NewFlextime(layouts, flextime.WithStrictDate())
+	for _, testCase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"Sun 2022-01-02", time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2022-01-02 002", time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2020-12-31 366", time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)},
+	} {
+		parsed, err := f.Parse(testCase.input)
+		require.NoError(t, err, "input = %s", testCase.input)
+		assert.Equal(t, testCase.expected, parsed)
+	}
+
+	var mismatch *flextime.DateMismatchError
+	_, err = f.Parse("Tue 2022-01-02")
+	require.True(t, errors.As(err, &mismatch), "err = %v", err)
+	require.NotNil(t, mismatch.Weekday)
+	assert.Equal(t, time.Tuesday, *mismatch.Weekday)
+	assert.Equal(t, time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC), mismatch.Date)
+
+	_, err = f.Parse("2022-01-02 005")
+	require.True(t, errors.As(err, &mismatch), "err = %v", err)
+	assert.Nil(t, mismatch.Weekday)
+	assert.Equal(t, 5, mismatch.YearDay)
+
+	// Localized names are checked as well.
+	de, err := flextime.NewLayoutSet(`ww, D. MMMM YYYY`)
+	require.NoError(t, err)
+	f = flextime.NewFlextime(de, flextime.WithLocale(locale.DE), flextime.WithStrictDate())
+	_, err = f.Parse("Sonntag, 2. Januar 2022")
+	assert.NoError(t, err)
+	_, err = f.Parse("Montag, 2. Januar 2022")
+	assert.True(t, errors.As(err, &mismatch), "err = %v", err)
+}
+
+func TestStrictDateYearInference(t *testing.T) {
+	layouts, err := flextime.NewLayoutSet(`w MMM D HH:mm:ss`)
+	require.NoError(t, err)
+	f := flextime.NewFlextime(
+		layouts,
+		flextime.WithStrictDate(),
+		flextime.WithYearInference(flextime.YearInference{
+			Now: func() time.Time { return time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC) },
+		}),
+	)
+
+	parsed, err := f.Parse("Sun Jan 2 15:04:05")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC), parsed)
+
+	var mismatch *flextime.DateMismatchError
+	_, err = f.Parse("Mon Jan 2 15:04:05")
+	assert.True(t, errors.As(err, &mismatch), "err = %v", err)
+}
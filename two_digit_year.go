@@ -0,0 +1,80 @@
+package flextime
+
+import "time"
+
+// TwoDigitYear resolves two-digit years of YY tokens into full years.
+type TwoDigitYear interface {
+	FullYear(yy int) int
+}
+
+// WithTwoDigitYear makes YY tokens resolve two-digit years by p,
+// instead of the fixed rule of the time package where 69-99 are 19xx and 00-68 are 20xx.
+// p may be FixedPivot, SlidingWindow, OracleRR or any other implementation.
+func WithTwoDigitYear(p TwoDigitYear) Option {
+	return optionFunc(func(o *options) {
+		o.twoDigitYear = p
+	})
+}
+
+// OracleRR is the RR rule of Oracle Database.
+// Two-digit years 00-49 are in the current century and 50-99 are in the previous one during the first half of a century,
+// and 00-49 are in the next century and 50-99 are in the current one during the second half.
+type OracleRR struct {
+	// Now returns the reference time. time.Now is used if nil.
+	Now func() time.Time
+}
+
+func (r OracleRR) FullYear(yy int) int {
+	year := nowOrDefault(r.Now).Year()
+	century := year - year%100
+	switch {
+	case year%100 < 50 && yy >= 50:
+		return century - 100 + yy
+	case year%100 >= 50 && yy < 50:
+		return century + 100 + yy
+	}
+	return century + yy
+}
+
+// FixedPivot resolves two-digit years into the 100 years starting from the pivot year.
+// For example, FixedPivot(1950) makes 50-99 1950-1999 and 00-49 2000-2049.
+// The rule of the time package is FixedPivot(1969).
+type FixedPivot int
+
+func (p FixedPivot) FullYear(yy int) int {
+	return windowYear(int(p), yy)
+}
+
+// SlidingWindow resolves two-digit years into the 100 years ending Future years after the current year.
+// For example, Future of 0 makes all years in the past, which suits birth dates,
+// and Future of 20 suits expiry dates, which are mostly in the near future.
+type SlidingWindow struct {
+	// Now returns the reference time. time.Now is used if nil.
+	Now func() time.Time
+	// Future is the number of years the window extends after the current year, which should be 0 through 99.
+	Future int
+}
+
+func (w SlidingWindow) FullYear(yy int) int {
+	return windowYear(nowOrDefault(w.Now).Year()+w.Future-99, yy)
+}
+
+// windowYear returns the year in start through start+99 whose last two digits are yy.
+func windowYear(start, yy int) int {
+	year := start - mod(start, 100) + yy
+	if year < start {
+		year += 100
+	}
+	return year
+}
+
+func mod(x, y int) int {
+	return (x%y + y) % y
+}
+
+func nowOrDefault(now func() time.Time) time.Time {
+	if now != nil {
+		return now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,62 @@
+package flextime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwoDigitYear(t *testing.T) {
+	// Do is an extension token, so the value goes through the scanner.
+	numeric, err := flextime.NewLayoutSet(`YY-MM-DD`)
+	require.NoError(t, err)
+	ordinal, err := flextime.NewExtendedLayoutSet(`Do MMM YY`)
+	require.NoError(t, err)
+	layouts := numeric.AddLayout(ordinal)
+
+	now := func() time.Time { return time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC) }
+	for _, testCase := range []struct {
+		name     string
+		policy   flextime.TwoDigitYear
+		expected map[string]int
+	}{
+		{
+			name:   "pivot 1950",
+			policy: flextime.FixedPivot(1950),
+			expected: map[string]int{
+				"50-01-02": 1950, "99-01-02": 1999, "00-01-02": 2000, "49-01-02": 2049, "2nd Jan 70": 1970,
+			},
+		},
+		{
+			name:   "pivot 1969, same as the time package",
+			policy: flextime.FixedPivot(1969),
+			expected: map[string]int{
+				"69-01-02": 1969, "68-01-02": 2068,
+			},
+		},
+		{
+			name:   "birth dates",
+			policy: flextime.SlidingWindow{Now: now},
+			expected: map[string]int{
+				"23-01-02": 2023, "24-01-02": 1924, "00-01-02": 2000, "99-01-02": 1999, "2nd Jan 30": 1930,
+			},
+		},
+		{
+			name:   "expiry dates",
+			policy: flextime.SlidingWindow{Now: now, Future: 20},
+			expected: map[string]int{
+				"43-01-02": 2043, "44-01-02": 1944, "2nd Jan 30": 2030,
+			},
+		},
+	} {
+		f := flextime.NewFlextime(layouts, flextime.WithTwoDigitYear(testCase.policy))
+		for input, year := range testCase.expected {
+			parsed, err := f.Parse(input)
+			require.NoError(t, err, "%s: input = %s", testCase.name, input)
+			assert.Equal(t, year, parsed.Year(), "%s: input = %s", testCase.name, input)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package flextime
+
+import (
+	"fmt"
+	"time"
+)
+
+// Week dates of ISO 8601 have no flextime token. They are written in layouts of ISO8601Extended and ISO8601Basic only.
+const (
+	// weekDateExtended is a week date in the extended format, e.g. 2022-W01-1.
+	weekDateExtended = "Www-D"
+	// weekDateBasic is a week date in the basic format, e.g. 2022W011.
+	weekDateBasic = "WwwD"
+)
+
+// parseWeekDate parses a week date of ISO 8601, the year, the week of the year and the day of the week from Monday,
+// and rewrites it into the calendar date.
+func parseWeekDate(extended bool) func(s *scanner) bool {
+	return func(s *scanner) bool {
+		var sep string
+		if extended {
+			sep = "-"
+		}
+		rest := s.rest
+		year, ok := weekDateNumber(&rest, "", 4)
+		if !ok {
+			return false
+		}
+		week, ok := weekDateNumber(&rest, sep+"W", 2)
+		if !ok {
+			return false
+		}
+		day, ok := weekDateNumber(&rest, sep, 1)
+		if !ok || day < 1 || day > 7 {
+			return false
+		}
+
+		date, ok := fromWeekDate(year, week, day)
+		if !ok {
+			return false
+		}
+		s.emit("2006-01-02", fmt.Sprintf("%04d-%02d-%02d", date.Year(), date.Month(), date.Day()), len(s.rest)-len(rest))
+		return true
+	}
+}
+
+// weekDateNumber consumes prefix and digits digits from the head of rest.
+func weekDateNumber(rest *string, prefix string, digits int) (int, bool) {
+	if len(*rest) < len(prefix) || (*rest)[:len(prefix)] != prefix {
+		return 0, false
+	}
+	value := (*rest)[len(prefix):]
+	if n, ok := numLen(value, digits, digits); !ok || n != digits {
+		return 0, false
+	}
+	num := 0
+	for _, c := range value[:digits] {
+		num = num*10 + int(c-'0')
+	}
+	*rest = value[digits:]
+	return num, true
+}
+
+// fromWeekDate returns the calendar date of day of week of year. It reports false if year has no such week.
+func fromWeekDate(year, week, day int) (time.Time, bool) {
+	// The first week of a year is the one which has January 4.
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	firstMonday := jan4.AddDate(0, 0, -isoWeekday(jan4)+1)
+	date := firstMonday.AddDate(0, 0, (week-1)*7+day-1)
+	if y, w := date.ISOWeek(); y != year || w != week {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// isoWeekday returns the day of the week of t, 1 for Monday through 7 for Sunday.
+func isoWeekday(t time.Time) int {
+	return (int(t.Weekday())+6)%7 + 1
+}
+
+func formatWeekDate(extended bool) func(o *options, t time.Time) string {
+	return func(o *options, t time.Time) string {
+		year, week := t.ISOWeek()
+		if extended {
+			return fmt.Sprintf("%04d-W%02d-%d", year, week, isoWeekday(t))
+		}
+		return fmt.Sprintf("%04dW%02d%d", year, week, isoWeekday(t))
+	}
+}
@@ -0,0 +1,66 @@
+package flextime
+
+import "time"
+
+// YearInference is a policy to infer the year of values whose layout has no year, e.g. RFC 3164 syslog timestamps.
+// It is a preset of ReferenceTime which fills the year only.
+//
+// The year is taken from Now. If the value would be later than Now by more than MaxFuture,
+// it is thought of as of the previous year, so that a December entry read in January belongs to the previous year.
+// Values are never put in the year after Now; WithReferenceTime does so around the turn of a year,
+// and also fills dates and months.
+type YearInference struct {
+	// Now returns the reference time. time.Now is used if nil.
+	Now func() time.Time
+	// MaxFuture is a tolerance for values later than Now, e.g. by clock skew.
+	MaxFuture time.Duration
+}
+
+// WithYearInference makes Flextime fill the year of values whose layout has no year according to y.
+// It replaces WithReferenceTime, and vice versa: the one passed later is used.
+func WithYearInference(y YearInference) Option {
+	return WithReferenceTime(ReferenceTime{Now: y.Now, MaxFuture: y.MaxFuture, yearOnly: true})
+}
+
+func withYear(t time.Time, year int) time.Time {
+	return time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+func validDate(year int, month time.Month, day int) bool {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Day() == day
+}
+
+// hasYearChunk reports whether chunks has a token which carries a year.
+func hasYearChunk(chunks []layoutChunk) bool {
+	for _, c := range chunks {
+		switch {
+		case c.kind == stdChunk && (c.value == "2006" || c.value == "06"),
+			c.kind == extChunk && (c.value == "E" || c.value == "EE" || c.value == "EEEE" || isExpandedYearToken(c.value) ||
+				c.value == weekDateExtended || c.value == weekDateBasic):
+			return true
+		}
+	}
+	return false
+}
+
+// hasDateChunk reports whether chunks has a token which carries a part of date.
+func hasDateChunk(chunks []layoutChunk) bool {
+	for _, c := range chunks {
+		switch c.kind {
+		case stdChunk:
+			switch c.value {
+			case "2006", "06", "1", "01", "Jan", "January", "2", "02", "_2", "002", "__2":
+				return true
+			}
+		case extChunk:
+			switch c.value {
+			case "Do", "E", "EE", "EEEE", weekDateExtended, weekDateBasic:
+				return true
+			}
+			if isExpandedYearToken(c.value) {
+				return true
+			}
+		}
+	}
+	return false
+}
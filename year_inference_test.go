@@ -0,0 +1,63 @@
+package flextime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYearInference(t *testing.T) {
+	now := func() time.Time { return time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC) }
+
+	p := flextime.NewFlextime(
+		flextime.RFC3164Syslog,
+		flextime.WithYearInference(flextime.YearInference{Now: now, MaxFuture: 24 * time.Hour}),
+	)
+	testPredefined(t, p, []predefinedTestCase{
+		// tolerated as clock skew.
+		{"Mar  1 12:00:00", time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{"Mar  2 12:00:00", time.Date(2022, 3, 2, 12, 0, 0, 0, time.UTC)},
+		// 2023 is not a leap year.
+		{"Feb 29 12:00:00", time.Date(2020, 2, 29, 12, 0, 0, 0, time.UTC)},
+	})
+
+	parsed, err := p.ParseInLocation("Jan  2 15:04:05", jst)
+	require.NoError(t, err)
+	require.True(t, time.Date(2023, 1, 2, 15, 4, 5, 0, jst).Equal(parsed), "actual = %s", parsed)
+
+	// values which have year are left as is.
+	parsed, err = flextime.NewFlextime(
+		flextime.RFC3339,
+		flextime.WithYearInference(flextime.YearInference{Now: now}),
+	).Parse("2030-01-02T15:04:05Z")
+	require.NoError(t, err)
+	require.Equal(t, 2030, parsed.Year())
+
+	// unlike WithReferenceTime, the year after Now is never taken.
+	endOfYear := func() time.Time { return time.Date(2022, 12, 31, 23, 59, 0, 0, time.UTC) }
+	parsed, err = flextime.NewFlextime(
+		flextime.RFC3164Syslog,
+		flextime.WithYearInference(flextime.YearInference{Now: endOfYear, MaxFuture: time.Hour}),
+	).Parse("Jan  1 00:10:00")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2022, 1, 1, 0, 10, 0, 0, time.UTC), parsed)
+
+	// WithYearInference and WithReferenceTime replace each other. The one passed later is used.
+	parsed, err = flextime.NewFlextime(
+		flextime.RFC3164Syslog,
+		flextime.WithYearInference(flextime.YearInference{Now: endOfYear, MaxFuture: time.Hour}),
+		flextime.WithReferenceTime(flextime.ReferenceTime{Now: endOfYear, MaxFuture: time.Hour}),
+	).Parse("Jan  1 00:10:00")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, 1, 1, 0, 10, 0, 0, time.UTC), parsed)
+
+	parsed, err = flextime.NewFlextime(
+		flextime.RFC3164Syslog,
+		flextime.WithReferenceTime(flextime.ReferenceTime{Now: endOfYear, MaxFuture: time.Hour}),
+		flextime.WithYearInference(flextime.YearInference{Now: endOfYear, MaxFuture: time.Hour}),
+	).Parse("Jan  1 00:10:00")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2022, 1, 1, 0, 10, 0, 0, time.UTC), parsed)
+}
@@ -0,0 +1,357 @@
+package flextime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ZoneAbbr is a meaning of a zone abbreviation.
+type ZoneAbbr struct {
+	// Region tells the meaning from others of the same abbreviation, e.g. US or China for CST.
+	Region string
+	// Offset is seconds east of UTC.
+	Offset int
+}
+
+// ZoneAbbrTable maps upper case zone abbreviations into their meanings, the default one first.
+type ZoneAbbrTable map[string][]ZoneAbbr
+
+const secondsPerHour = 60 * 60
+
+// DefaultZoneAbbrs is a table of widely used zone abbreviations.
+// Ambiguous ones default to their meanings in RFC 5322 and North America, e.g. CST is US Central Standard Time.
+var DefaultZoneAbbrs = ZoneAbbrTable{
+	// RFC 5322, section 4.3.
+	"UT":  {{"", 0}},
+	"GMT": {{"", 0}},
+	"EST": {{"US", -5 * secondsPerHour}},
+	"EDT": {{"US", -4 * secondsPerHour}},
+	"CST": {{"US", -6 * secondsPerHour}, {"China", 8 * secondsPerHour}, {"Cuba", -5 * secondsPerHour}},
+	"CDT": {{"US", -5 * secondsPerHour}, {"Cuba", -4 * secondsPerHour}},
+	"MST": {{"US", -7 * secondsPerHour}},
+	"MDT": {{"US", -6 * secondsPerHour}},
+	"PST": {{"US", -8 * secondsPerHour}, {"Philippines", 8 * secondsPerHour}},
+	"PDT": {{"US", -7 * secondsPerHour}},
+
+	"UTC":  {{"", 0}},
+	"AKST": {{"US", -9 * secondsPerHour}},
+	"AKDT": {{"US", -8 * secondsPerHour}},
+	"HST":  {{"US", -10 * secondsPerHour}},
+	"AST":  {{"Atlantic", -4 * secondsPerHour}, {"Arabia", 3 * secondsPerHour}},
+	"ADT":  {{"Atlantic", -3 * secondsPerHour}},
+	"NST":  {{"Canada", -3*secondsPerHour - 30*60}},
+	"NDT":  {{"Canada", -2*secondsPerHour - 30*60}},
+	"WET":  {{"", 0}},
+	"WEST": {{"", 1 * secondsPerHour}},
+	"BST":  {{"UK", 1 * secondsPerHour}, {"Bangladesh", 6 * secondsPerHour}},
+	"IST":  {{"India", 5*secondsPerHour + 30*60}, {"Ireland", 1 * secondsPerHour}, {"Israel", 2 * secondsPerHour}},
+	"IDT":  {{"Israel", 3 * secondsPerHour}},
+	"CET":  {{"", 1 * secondsPerHour}},
+	"CEST": {{"", 2 * secondsPerHour}},
+	"EET":  {{"", 2 * secondsPerHour}},
+	"EEST": {{"", 3 * secondsPerHour}},
+	"MSK":  {{"Russia", 3 * secondsPerHour}},
+	"WAT":  {{"Africa", 1 * secondsPerHour}},
+	"CAT":  {{"Africa", 2 * secondsPerHour}},
+	"EAT":  {{"Africa", 3 * secondsPerHour}},
+	"SAST": {{"Africa", 2 * secondsPerHour}},
+	"PKT":  {{"Pakistan", 5 * secondsPerHour}},
+	"NPT":  {{"Nepal", 5*secondsPerHour + 45*60}},
+	"ICT":  {{"Indochina", 7 * secondsPerHour}},
+	"WIB":  {{"Indonesia", 7 * secondsPerHour}},
+	"HKT":  {{"Hong Kong", 8 * secondsPerHour}},
+	"SGT":  {{"Singapore", 8 * secondsPerHour}},
+	"PHT":  {{"Philippines", 8 * secondsPerHour}},
+	"AWST": {{"Australia", 8 * secondsPerHour}},
+	"JST":  {{"Japan", 9 * secondsPerHour}},
+	"KST":  {{"Korea", 9 * secondsPerHour}},
+	"ACST": {{"Australia", 9*secondsPerHour + 30*60}},
+	"ACDT": {{"Australia", 10*secondsPerHour + 30*60}},
+	"AEST": {{"Australia", 10 * secondsPerHour}},
+	"AEDT": {{"Australia", 11 * secondsPerHour}},
+	"NZST": {{"New Zealand", 12 * secondsPerHour}},
+	"NZDT": {{"New Zealand", 13 * secondsPerHour}},
+}
+
+// RFC5322ZoneAbbrs is a table of the zone names defined in RFC 5322, section 4.3.
+var RFC5322ZoneAbbrs = ZoneAbbrTable{
+	"UT":  {{"", 0}},
+	"GMT": {{"", 0}},
+	"EST": {{"US", -5 * secondsPerHour}},
+	"EDT": {{"US", -4 * secondsPerHour}},
+	"CST": {{"US", -6 * secondsPerHour}},
+	"CDT": {{"US", -5 * secondsPerHour}},
+	"MST": {{"US", -7 * secondsPerHour}},
+	"MDT": {{"US", -6 * secondsPerHour}},
+	"PST": {{"US", -8 * secondsPerHour}},
+	"PDT": {{"US", -7 * secondsPerHour}},
+}
+
+// ZoneResolver resolves zone abbreviations into offsets.
+type ZoneResolver struct {
+	// Table is DefaultZoneAbbrs if nil.
+	Table ZoneAbbrTable
+	// Prefer maps ambiguous abbreviations to regions in the order of preference,
+	// e.g. {"CST": {"China"}, "IST": {"Israel"}}.
+	// If none of them is in the table, the first meaning in the table is taken.
+	Prefer map[string][]string
+	// Strict makes unknown abbreviations fail to parse.
+	// Otherwise they are left to time.Parse, which gives them zero offset unless the location knows them.
+	Strict bool
+}
+
+// WithZoneResolver makes Flextime resolve zone abbreviations of z and MST tokens by r.
+// Without it, z resolves by DefaultZoneAbbrs and MST is left to time.Parse.
+func WithZoneResolver(r ZoneResolver) Option {
+	return optionFunc(func(o *options) {
+		o.zoneResolver = &r
+	})
+}
+
+func (o *options) zoneResolverOrDefault() *ZoneResolver {
+	if o.zoneResolver != nil {
+		return o.zoneResolver
+	}
+	return &ZoneResolver{}
+}
+
+// Resolve returns offset in seconds east of UTC of abbr, which is case-sensitive.
+func (r ZoneResolver) Resolve(abbr string) (offset int, ok bool) {
+	table := r.Table
+	if table == nil {
+		table = DefaultZoneAbbrs
+	}
+	candidates := table[abbr]
+	if len(candidates) == 0 {
+		return 0, false
+	}
+	for _, region := range r.Prefer[abbr] {
+		for _, c := range candidates {
+			if c.Region == region {
+				return c.Offset, true
+			}
+		}
+	}
+	return candidates[0].Offset, true
+}
+
+// parseZoneAbbr parses a zone abbreviation and rewrites it into a numeric offset, keeping the name.
+// Unknown abbreviations are left to time.Parse unless the resolver is strict.
+func parseZoneAbbr(s *scanner) bool {
+	value := s.rest
+	if s.opts.caseInsensitive {
+		value = upperASCII(value)
+	}
+
+	n := 0
+	for n < len(value) && 'A' <= value[n] && value[n] <= 'Z' {
+		n++
+	}
+	resolver := s.opts.zoneResolverOrDefault()
+	if offset, ok := resolver.Resolve(value[:n]); ok {
+		s.emitZone(value[:n], offset, n)
+		return true
+	}
+	if resolver.Strict {
+		return false
+	}
+
+	n, ok := zoneNameLen(value)
+	if !ok {
+		return false
+	}
+	s.emit("MST", value[:n], n)
+	return true
+}
+
+// emitZone writes a zone with name and offset in seconds, consuming n bytes of the input.
+// The name is dropped if time.Parse does not accept it as a zone abbreviation.
+func (s *scanner) emitZone(name string, offset int, n int) {
+	numeric := formatOffset(offset)
+	if l, ok := zoneNameLen(name); ok && l == len(name) {
+		s.emit("MST -07:00:00", name+" "+numeric, n)
+		return
+	}
+	s.emit("-07:00:00", numeric, n)
+}
+
+// formatOffset formats offset in seconds in the form of -07:00:00.
+func formatOffset(offset int) string {
+	return time.Date(2000, 1, 1, 0, 0, 0, 0, time.FixedZone("", offset)).Format("-07:00:00")
+}
+
+func formatZoneAbbr(_ *options, t time.Time) string {
+	return t.Format("MST")
+}
+
+// parseObsZone parses obs-zone of RFC 5322, section 4.3: names of RFC5322ZoneAbbrs,
+// and other alphabetic zones, military letters included, which RFC 5322 regards as -0000, UTC of an unknown local time.
+// It is not affected by WithZoneResolver.
+func parseObsZone(s *scanner) bool {
+	value := s.rest
+	if s.opts.caseInsensitive {
+		value = upperASCII(value)
+	}
+
+	n := 0
+	for n < len(value) && ('A' <= value[n] && value[n] <= 'Z' || 'a' <= value[n] && value[n] <= 'z') {
+		n++
+	}
+	if n == 0 {
+		return false
+	}
+	if offset, ok := (ZoneResolver{Table: RFC5322ZoneAbbrs}).Resolve(value[:n]); ok {
+		s.emitZone(value[:n], offset, n)
+		return true
+	}
+	s.emit("-0700", "-0000", n)
+	return true
+}
+
+// formatObsZone formats the offset of t numerically, as RFC 5322 requires for new messages.
+func formatObsZone(_ *options, t time.Time) string {
+	return t.Format("-0700")
+}
+
+// offsetPrefixes are prefixes of localized GMT offsets, longest first.
+var offsetPrefixes = []string{"GMT", "UTC", "UT"}
+
+// parsePrefixedOffset parses an offset prefixed with GMT, UTC or UT, e.g. GMT+9, UTC+09:00, GMT+0530 or GMT alone.
+// Hours have one or two digits and minutes are optional, with or without a colon.
+func parsePrefixedOffset(s *scanner) bool {
+	hasPrefix := strings.HasPrefix
+	if s.opts.caseInsensitive {
+		hasPrefix = hasPrefixFold
+	}
+	n := 0
+	for _, p := range offsetPrefixes {
+		if hasPrefix(s.rest, p) {
+			n = len(p)
+			break
+		}
+	}
+	if n == 0 {
+		return false
+	}
+
+	offset, l, ok := looseOffset(s.rest[n:])
+	if !ok {
+		return false
+	}
+	n += l
+	if offset == 0 {
+		s.emit("Z07:00", "Z", n)
+		return true
+	}
+	s.emit("-07:00:00", formatOffset(offset), n)
+	return true
+}
+
+// looseOffset parses a signed offset of one or two digit hours and optional minutes at the head of value,
+// e.g. +9, -09, +5:30, +0530. It returns zero offset without consuming anything if value does not start with a sign.
+func looseOffset(value string) (offset int, n int, ok bool) {
+	if len(value) == 0 || (value[0] != '+' && value[0] != '-') {
+		return 0, 0, true
+	}
+	digits, _ := numLen(value[1:], 0, 4)
+	var hh, mm int
+	n = 1 + digits
+	switch digits {
+	case 1, 2:
+		hh, _ = strconv.Atoi(value[1:n])
+		if n+3 <= len(value) && value[n] == ':' {
+			if l, _ := numLen(value[n+1:], 0, 3); l != 2 {
+				return 0, 0, false
+			}
+			mm, _ = strconv.Atoi(value[n+1 : n+3])
+			n += 3
+		}
+	case 3, 4:
+		hh, _ = strconv.Atoi(value[1 : n-2])
+		mm, _ = strconv.Atoi(value[n-2 : n])
+	default:
+		return 0, 0, false
+	}
+	if hh > 23 || mm > 59 {
+		return 0, 0, false
+	}
+	offset = (hh*60 + mm) * 60
+	if value[0] == '-' {
+		offset = -offset
+	}
+	return offset, n, true
+}
+
+// formatPrefixedOffset formats the offset of t prefixed with GMT, e.g. GMT+9 and GMT+5:30 in short,
+// or GMT+09:00 in long. Zero offset is GMT alone.
+func formatPrefixedOffset(long bool) func(o *options, t time.Time) string {
+	return func(_ *options, t time.Time) string {
+		_, offset := t.Zone()
+		if offset == 0 {
+			return "GMT"
+		}
+		if long {
+			return "GMT" + t.Format("-07:00")
+		}
+		sign := "+"
+		if offset < 0 {
+			sign, offset = "-", -offset
+		}
+		formatted := "GMT" + sign + strconv.Itoa(offset/secondsPerHour)
+		if mm := offset % secondsPerHour / 60; mm != 0 {
+			formatted += fmt.Sprintf(":%02d", mm)
+		}
+		return formatted
+	}
+}
+
+// parseMilitaryZone parses a military zone letter: A through M, skipping J, are +1 through +12,
+// N through Y are -1 through -12 and Z is UTC.
+// Note that RFC 5322 regards them as -0000, unknown offset, since RFC 822 defined their signs reversed.
+func parseMilitaryZone(s *scanner) bool {
+	if len(s.rest) == 0 {
+		return false
+	}
+	c := s.rest[0]
+	if s.opts.caseInsensitive && 'a' <= c && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	offset, ok := militaryOffset(c)
+	if !ok {
+		return false
+	}
+	if offset == 0 {
+		s.emit("Z07:00", "Z", 1)
+		return true
+	}
+	s.emit("-07:00:00", formatOffset(offset), 1)
+	return true
+}
+
+func militaryOffset(c byte) (int, bool) {
+	switch {
+	case 'A' <= c && c <= 'I':
+		return int(c-'A'+1) * secondsPerHour, true
+	case 'K' <= c && c <= 'M':
+		return int(c-'K'+10) * secondsPerHour, true
+	case 'N' <= c && c <= 'Y':
+		return -int(c-'N'+1) * secondsPerHour, true
+	case c == 'Z':
+		return 0, true
+	}
+	return 0, false
+}
+
+// formatMilitaryZone formats the offset of t as a military zone letter.
+// Offsets which have no letter are formatted as -0700.
+func formatMilitaryZone(_ *options, t time.Time) string {
+	_, offset := t.Zone()
+	for c := byte('A'); c <= 'Z'; c++ {
+		if o, ok := militaryOffset(c); ok && o == offset {
+			return string(c)
+		}
+	}
+	return t.Format("-0700")
+}
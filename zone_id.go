@@ -0,0 +1,189 @@
+package flextime
+
+import (
+	"strings"
+	"time"
+)
+
+// LocationProvider loads the location of an IANA time zone identifier, e.g. Asia/Tokyo.
+type LocationProvider func(name string) (*time.Location, error)
+
+// WithLocationProvider replaces how zone identifiers of zzzz tokens and RFC 9557 suffixes are loaded.
+// The default is time.LoadLocation.
+func WithLocationProvider(p LocationProvider) Option {
+	return optionFunc(func(o *options) {
+		o.locationProvider = p
+	})
+}
+
+func (o *options) loadLocation(name string) (*time.Location, error) {
+	if o.locationProvider != nil {
+		return o.locationProvider(name)
+	}
+	return time.LoadLocation(name)
+}
+
+// zoneIDLen returns the length of an IANA time zone identifier at the head of value,
+// e.g. Asia/Tokyo, America/Argentina/Buenos_Aires or Etc/GMT+9.
+func zoneIDLen(value string) int {
+	n := 0
+	for ; n < len(value); n++ {
+		c := value[n]
+		if !('A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '/' || c == '_' || c == '-' || c == '+') {
+			break
+		}
+	}
+	return n
+}
+
+func parseZoneID(s *scanner) bool {
+	n := zoneIDLen(s.rest)
+	if n == 0 {
+		return false
+	}
+	loc, err := s.opts.loadLocation(s.rest[:n])
+	if err != nil {
+		return false
+	}
+	s.fields.location = loc
+	s.emit("", "", n)
+	return true
+}
+
+func formatZoneID(_ *options, t time.Time) string {
+	if name := t.Location().String(); name != "" && name != "Local" {
+		return name
+	}
+	return t.Format("-07:00")
+}
+
+// applyLocation returns t in loc.
+//
+// If the layout has no offset, the wall clock of t is taken in loc, resolved by the DST policy.
+// Times in UTC, e.g. parsed from Z, are just converted, as RFC 9557 takes Z as an unknown local offset.
+// Otherwise the offset of t must match one of loc at the instant.
+// If it does not, false is returned if critical, and t is returned as is otherwise.
+func (o *options) applyLocation(t time.Time, loc *time.Location, hasOffset, critical bool) (time.Time, bool, error) {
+	if !hasOffset {
+		resolved, err := resolveLocal(t, loc, o.dstPolicy)
+		return resolved, err == nil, err
+	}
+	if t.Location() == time.UTC {
+		return t.In(loc), true, nil
+	}
+	_, offset := t.Zone()
+	in := t.In(loc)
+	if _, want := in.Zone(); offset != want {
+		return t, !critical, nil
+	}
+	return in, true, nil
+}
+
+// hasOffsetChunk reports whether chunks has a token which carries a zone offset or an abbreviation.
+func hasOffsetChunk(chunks []layoutChunk) bool {
+	for _, c := range chunks {
+		switch {
+		case c.kind == stdChunk && (c.value == "MST" || strings.HasPrefix(c.value, "Z07") || strings.HasPrefix(c.value, "-07")),
+			c.kind == extChunk && (c.value == "z" || c.value == "O" || c.value == "OOOO" || c.value == "N" || c.value == "obs-zone"):
+			return true
+		}
+	}
+	return false
+}
+
+// WithRFC9557Suffix makes Flextime accept suffixes of RFC 9557 after values,
+// e.g. [Asia/Tokyo] of 2022-01-02T15:04:05+09:00[Asia/Tokyo], and format the location of times as the suffix.
+//
+// The time zone suffix converts the result into its location. Its offset must match the one of the value,
+// unless the value is Z. An inconsistent suffix fails to parse if it is critical, e.g. [!Asia/Tokyo], and is ignored otherwise.
+// Of extension suffixes, u-ca is accepted only for gregory and iso8601.
+// Unknown ones fail to parse if critical, e.g. [!u-ca=japanese], and are ignored otherwise.
+func WithRFC9557Suffix() Option {
+	return optionFunc(func(o *options) {
+		o.rfc9557 = true
+	})
+}
+
+type suffixTag struct {
+	critical bool
+	key      string
+	value    string
+}
+
+// splitRFC9557Suffix splits value into a time and its suffix tags.
+// It returns a non-empty message if the suffix is malformed.
+func splitRFC9557Suffix(value string) (string, []suffixTag, string) {
+	var tags []suffixTag
+	for strings.HasSuffix(value, "]") {
+		open := strings.LastIndexByte(value, '[')
+		if open < 0 {
+			return "", nil, "unbalanced suffix"
+		}
+		content := value[open+1 : len(value)-1]
+		value = value[:open]
+
+		var tag suffixTag
+		if strings.HasPrefix(content, "!") {
+			tag.critical = true
+			content = content[1:]
+		}
+		if key, val, ok := strings.Cut(content, "="); ok {
+			tag.key, tag.value = key, val
+		} else {
+			tag.value = content
+		}
+		if tag.value == "" {
+			return "", nil, "empty suffix"
+		}
+		tags = append([]suffixTag{tag}, tags...)
+	}
+	for i, tag := range tags {
+		if tag.key == "" && i != 0 {
+			return "", nil, "time zone suffix must come first"
+		}
+	}
+	return value, tags, ""
+}
+
+// applySuffix applies suffix tags to t parsed in layout.
+func (o *options) applySuffix(t time.Time, layout *compiledLayout, tags []suffixTag) (time.Time, string) {
+	for _, tag := range tags {
+		switch tag.key {
+		case "":
+			loc, err := o.loadLocation(tag.value)
+			if err != nil {
+				if tag.critical {
+					return time.Time{}, "unknown time zone " + tag.value
+				}
+				continue
+			}
+			var ok bool
+			t, ok, err = o.applyLocation(t, loc, layout.hasOffset, tag.critical)
+			if err != nil {
+				return time.Time{}, err.Error()
+			}
+			if !ok {
+				return time.Time{}, "offset is inconsistent with time zone " + tag.value
+			}
+		case "u-ca":
+			if tag.critical && tag.value != "gregory" && tag.value != "iso8601" {
+				return time.Time{}, "unsupported calendar " + tag.value
+			}
+		default:
+			if tag.critical {
+				return time.Time{}, "unknown critical suffix " + tag.key
+			}
+		}
+	}
+	return t, ""
+}
+
+// formatRFC9557Suffix returns the time zone suffix of t, or empty if the location of t has no identifier.
+func formatRFC9557Suffix(t time.Time) string {
+	name := t.Location().String()
+	if t.Location() == time.UTC || name == "" || name == "Local" {
+		return ""
+	}
+	return "[" + name + "]"
+}
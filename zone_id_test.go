@@ -0,0 +1,112 @@
+package flextime_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	fakeTokyo   = time.FixedZone("Asia/Tokyo", 9*60*60)
+	fakeKolkata = time.FixedZone("Asia/Kolkata", 5*60*60+30*60)
+)
+
+func fakeLocations(name string) (*time.Location, error) {
+	switch name {
+	case "Asia/Tokyo":
+		return fakeTokyo, nil
+	case "Asia/Kolkata":
+		return fakeKolkata, nil
+	}
+	return nil, errors.New("unknown time zone " + name)
+}
+
+func TestZoneIDToken(t *testing.T) {
+	layouts, err := flextime.NewExtendedLayoutSet(`YYYY-MM-DD HH:mm:ss[ -07:00] zzzz`)
+	require.NoError(t, err)
+	f := flextime.NewFlextime(layouts, flextime.WithLocationProvider(fakeLocations))
+
+	for _, testCase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"2022-01-02 15:04:05 Asia/Tokyo", time.Date(2022, 1, 2, 15, 4, 5, 0, fakeTokyo)},
+		{"2022-01-02 15:04:05 +09:00 Asia/Tokyo", time.Date(2022, 1, 2, 15, 4, 5, 0, fakeTokyo)},
+		{"2022-01-02 15:04:05 Asia/Kolkata", time.Date(2022, 1, 2, 15, 4, 5, 0, fakeKolkata)},
+	} {
+		parsed, err := f.Parse(testCase.input)
+		require.NoError(t, err, testCase.input)
+		assert.Equal(t, testCase.expected, parsed, testCase.input)
+		assert.Same(t, testCase.expected.Location(), parsed.Location(), testCase.input)
+	}
+
+	for _, invalid := range []string{
+		"2022-01-02 15:04:05 +08:00 Asia/Tokyo",
+		"2022-01-02 15:04:05 Europe/London",
+		"2022-01-02 15:04:05 ",
+	} {
+		_, err := f.Parse(invalid)
+		var parseErr *time.ParseError
+		assert.ErrorAs(t, err, &parseErr, invalid)
+	}
+
+	assert.Equal(t, "2022-01-02 15:04:05 +09:00 Asia/Tokyo", f.Format(time.Date(2022, 1, 2, 15, 4, 5, 0, fakeTokyo)))
+}
+
+func TestRFC9557Suffix(t *testing.T) {
+	f := flextime.NewFlextime(flextime.RFC3339, flextime.WithRFC9557Suffix(), flextime.WithLocationProvider(fakeLocations))
+
+	instant := time.Date(2022, 1, 2, 6, 4, 5, 0, time.UTC)
+	for _, testCase := range []struct {
+		input    string
+		expected *time.Location
+	}{
+		{"2022-01-02T15:04:05+09:00", nil},
+		{"2022-01-02T15:04:05+09:00[Asia/Tokyo]", fakeTokyo},
+		{"2022-01-02T15:04:05+09:00[!Asia/Tokyo]", fakeTokyo},
+		{"2022-01-02T06:04:05Z[Asia/Tokyo]", fakeTokyo},
+		{"2022-01-02T11:34:05+05:30[Asia/Kolkata][u-ca=gregory]", fakeKolkata},
+		{"2022-01-02T15:04:05+09:00[Asia/Tokyo][!u-ca=iso8601][foo=bar]", fakeTokyo},
+		// inconsistent but not critical, the zone is ignored.
+		{"2022-01-02T15:04:05+09:00[Asia/Kolkata]", nil},
+		{"2022-01-02T15:04:05+09:00[Europe/London]", nil},
+		{"2022-01-02T15:04:05+09:00[u-ca=japanese]", nil},
+	} {
+		parsed, err := f.Parse(testCase.input)
+		require.NoError(t, err, testCase.input)
+		assert.True(t, instant.Equal(parsed), "input = %s, actual = %s", testCase.input, parsed)
+		if testCase.expected != nil {
+			assert.Same(t, testCase.expected, parsed.Location(), testCase.input)
+		}
+	}
+
+	for _, invalid := range []string{
+		"2022-01-02T15:04:05+09:00[!Asia/Kolkata]",
+		"2022-01-02T15:04:05+09:00[!Europe/London]",
+		"2022-01-02T15:04:05+09:00[!u-ca=japanese]",
+		"2022-01-02T15:04:05+09:00[!foo=bar]",
+		"2022-01-02T15:04:05+09:00[u-ca=gregory][Asia/Tokyo]",
+		"2022-01-02T15:04:05+09:00[]",
+		"2022-01-02T15:04:05+09:00Asia/Tokyo]",
+		"2022-01-02T15:04:65+09:00[Asia/Tokyo]",
+	} {
+		_, err := f.Parse(invalid)
+		var parseErr *time.ParseError
+		require.ErrorAs(t, err, &parseErr, invalid)
+		assert.Equal(t, invalid, parseErr.Value)
+	}
+
+	assert.Equal(t, "2022-01-02T15:04:05+09:00[Asia/Tokyo]", f.Format(time.Date(2022, 1, 2, 15, 4, 5, 0, fakeTokyo)))
+	assert.Equal(t, "2022-01-02T06:04:05Z", f.Format(instant))
+
+	// with the host tzdata.
+	parsed, err := flextime.RFC9557Parser.Parse("2022-07-01T12:00:00-04:00[America/New_York]")
+	require.NoError(t, err)
+	assert.Equal(t, "America/New_York", parsed.Location().String())
+	_, err = flextime.RFC9557Parser.Parse("2022-07-01T12:00:00-05:00[!America/New_York]")
+	assert.Error(t, err)
+}
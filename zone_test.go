@@ -0,0 +1,191 @@
+package flextime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/flextime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoneResolver(t *testing.T) {
+	layouts, err := flextime.NewLayoutSet(`YYYY-MM-DD HH:mm MST`)
+	require.NoError(t, err)
+
+	offsetOf := func(t *testing.T, f *flextime.Flextime, input string) int {
+		t.Helper()
+		parsed, err := f.Parse(input)
+		require.NoError(t, err, input)
+		_, offset := parsed.Zone()
+		return offset
+	}
+
+	// time.Parse invents zero offset.
+	assert.Equal(t, 0, offsetOf(t, flextime.NewFlextime(layouts), "2022-01-02 15:04 JST"))
+
+	f := flextime.NewFlextime(layouts, flextime.WithZoneResolver(flextime.ZoneResolver{}))
+	assert.Equal(t, 9*60*60, offsetOf(t, f, "2022-01-02 15:04 JST"))
+	assert.Equal(t, -6*60*60, offsetOf(t, f, "2022-01-02 15:04 CST"))
+	assert.Equal(t, 5*60*60+30*60, offsetOf(t, f, "2022-01-02 15:04 IST"))
+	assert.Equal(t, 60*60, offsetOf(t, f, "2022-01-02 15:04 BST"))
+	parsed, err := f.Parse("2022-01-02 15:04 JST")
+	require.NoError(t, err)
+	name, _ := parsed.Zone()
+	assert.Equal(t, "JST", name)
+	// unknown ones are left to time.Parse.
+	assert.Equal(t, 0, offsetOf(t, f, "2022-01-02 15:04 XYZ"))
+
+	f = flextime.NewFlextime(layouts, flextime.WithZoneResolver(flextime.ZoneResolver{
+		Prefer: map[string][]string{
+			"CST": {"China"},
+			"IST": {"Mars", "Israel", "Ireland"},
+			"BST": {"Mars"},
+		},
+	}))
+	assert.Equal(t, 8*60*60, offsetOf(t, f, "2022-01-02 15:04 CST"))
+	assert.Equal(t, 2*60*60, offsetOf(t, f, "2022-01-02 15:04 IST"))
+	assert.Equal(t, 60*60, offsetOf(t, f, "2022-01-02 15:04 BST"))
+
+	f = flextime.NewFlextime(layouts, flextime.WithZoneResolver(flextime.ZoneResolver{
+		Table:  flextime.ZoneAbbrTable{"XYZ": {{Offset: 3 * 60 * 60}}},
+		Strict: true,
+	}))
+	assert.Equal(t, 3*60*60, offsetOf(t, f, "2022-01-02 15:04 XYZ"))
+	_, err = f.Parse("2022-01-02 15:04 JST")
+	var parseErr *time.ParseError
+	assert.ErrorAs(t, err, &parseErr)
+
+	// z token resolves by the default table without the option.
+	z, err := flextime.NewExtendedLayoutSet(`YYYY-MM-DD HH:mm z`)
+	require.NoError(t, err)
+	assert.Equal(t, 9*60*60, offsetOf(t, flextime.NewFlextime(z), "2022-01-02 15:04 JST"))
+	assert.Equal(t, 9*60*60, offsetOf(t, flextime.NewFlextime(z, flextime.WithCaseInsensitive()), "2022-01-02 15:04 jst"))
+}
+
+func TestPrefixedOffset(t *testing.T) {
+	short, err := flextime.NewExtendedLayoutSet(`YYYY-MM-DD HH:mm O`)
+	require.NoError(t, err)
+	long, err := flextime.NewExtendedLayoutSet(`YYYY-MM-DD HH:mm OOOO`)
+	require.NoError(t, err)
+
+	for _, testCase := range []struct {
+		input  string
+		offset int
+	}{
+		{"2022-01-02 15:04 GMT+9", 9 * 60 * 60},
+		{"2022-01-02 15:04 GMT+09", 9 * 60 * 60},
+		{"2022-01-02 15:04 UTC+09:00", 9 * 60 * 60},
+		{"2022-01-02 15:04 GMT+0900", 9 * 60 * 60},
+		{"2022-01-02 15:04 GMT+5:30", 5*60*60 + 30*60},
+		{"2022-01-02 15:04 GMT+530", 5*60*60 + 30*60},
+		{"2022-01-02 15:04 UTC-8", -8 * 60 * 60},
+		{"2022-01-02 15:04 GMT", 0},
+		{"2022-01-02 15:04 UTC", 0},
+		{"2022-01-02 15:04 UT", 0},
+		{"2022-01-02 15:04 GMT-0", 0},
+	} {
+		for _, layouts := range []*flextime.LayoutSet{short, long} {
+			parsed, err := flextime.NewFlextime(layouts).Parse(testCase.input)
+			require.NoError(t, err, testCase.input)
+			_, offset := parsed.Zone()
+			assert.Equal(t, testCase.offset, offset, testCase.input)
+			assert.Equal(t, 15, parsed.Hour(), testCase.input)
+		}
+	}
+
+	for _, invalid := range []string{
+		"2022-01-02 15:04 +09:00",
+		"2022-01-02 15:04 GMT+",
+		"2022-01-02 15:04 GMT+24",
+		"2022-01-02 15:04 GMT+09:60",
+		"2022-01-02 15:04 GMT+09:0",
+		"2022-01-02 15:04 GMT+09000",
+		"2022-01-02 15:04 gmt+9",
+	} {
+		_, err := flextime.NewFlextime(short).Parse(invalid)
+		var parseErr *time.ParseError
+		assert.ErrorAs(t, err, &parseErr, invalid)
+	}
+	_, err = flextime.NewFlextime(short, flextime.WithCaseInsensitive()).Parse("2022-01-02 15:04 gmt+9")
+	assert.NoError(t, err)
+
+	india := time.FixedZone("", 5*60*60+30*60)
+	assert.Equal(t, "2022-01-02 15:04 GMT+9", flextime.NewFlextime(short).Format(time.Date(2022, 1, 2, 15, 4, 0, 0, jst)))
+	assert.Equal(t, "2022-01-02 15:04 GMT+5:30", flextime.NewFlextime(short).Format(time.Date(2022, 1, 2, 15, 4, 0, 0, india)))
+	assert.Equal(t, "2022-01-02 15:04 GMT", flextime.NewFlextime(short).Format(time.Date(2022, 1, 2, 15, 4, 0, 0, time.UTC)))
+	assert.Equal(t, "2022-01-02 15:04 GMT+09:00", flextime.NewFlextime(long).Format(time.Date(2022, 1, 2, 15, 4, 0, 0, jst)))
+	assert.Equal(t, "2022-01-02 15:04 GMT", flextime.NewFlextime(long).Format(time.Date(2022, 1, 2, 15, 4, 0, 0, time.UTC)))
+}
+
+func TestMilitaryZone(t *testing.T) {
+	layouts, err := flextime.NewExtendedLayoutSet(`DDHHmmN MMM YY`)
+	require.NoError(t, err)
+	f := flextime.NewFlextime(layouts)
+
+	for _, testCase := range []struct {
+		input  string
+		offset int
+	}{
+		{"021504A JAN 22", 1 * 60 * 60},
+		{"021504I JAN 22", 9 * 60 * 60},
+		{"021504K JAN 22", 10 * 60 * 60},
+		{"021504M JAN 22", 12 * 60 * 60},
+		{"021504N JAN 22", -1 * 60 * 60},
+		{"021504Y JAN 22", -12 * 60 * 60},
+		{"021504Z JAN 22", 0},
+	} {
+		parsed, err := f.Parse(testCase.input)
+		require.NoError(t, err, testCase.input)
+		_, offset := parsed.Zone()
+		assert.Equal(t, testCase.offset, offset, testCase.input)
+		assert.Equal(t, 15, parsed.Hour(), testCase.input)
+	}
+
+	for _, invalid := range []string{"021504J JAN 22", "021504z JAN 22", "0215041 JAN 22"} {
+		_, err := f.Parse(invalid)
+		var parseErr *time.ParseError
+		assert.ErrorAs(t, err, &parseErr, invalid)
+	}
+	_, err = flextime.NewFlextime(layouts, flextime.WithCaseInsensitive()).Parse("021504z JAN 22")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "021504I Jan 22", f.Format(time.Date(2022, 1, 2, 15, 4, 0, 0, jst)))
+	assert.Equal(t, "021504Z Jan 22", f.Format(time.Date(2022, 1, 2, 15, 4, 0, 0, time.UTC)))
+	assert.Equal(t, "021504+0530 Jan 22", f.Format(time.Date(2022, 1, 2, 15, 4, 0, 0, time.FixedZone("", 5*60*60+30*60))))
+}
+
+func TestRFC5322Zones(t *testing.T) {
+	for _, testCase := range []struct {
+		input  string
+		offset int
+	}{
+		{"Fri, 21 Nov 1997 09:55:06 GMT", 0},
+		{"Fri, 21 Nov 1997 09:55:06 CST", -6 * 60 * 60},
+		{"Fri, 21 Nov 1997 09:55:06 cdt", -5 * 60 * 60},
+		// other zones are -0000, not the ones of DefaultZoneAbbrs.
+		{"Fri, 21 Nov 1997 09:55:06 IST", 0},
+		{"Fri, 21 Nov 1997 09:55:06 BST", 0},
+		{"Fri, 21 Nov 1997 09:55:06 JST", 0},
+		// so are military zones, whose signs RFC 822 defined reversed.
+		{"Fri, 21 Nov 1997 09:55:06 A", 0},
+		{"Fri, 21 Nov 1997 09:55:06 z", 0},
+	} {
+		parsed, err := flextime.RFC5322DateParser.Parse(testCase.input)
+		require.NoError(t, err, testCase.input)
+		_, offset := parsed.Zone()
+		assert.Equal(t, testCase.offset, offset, testCase.input)
+		assert.Equal(t, 9, parsed.Hour(), testCase.input)
+	}
+
+	// WithZoneResolver does not change zones of RFC 5322.
+	f := flextime.NewFlextime(flextime.RFC5322Date, flextime.WithZoneResolver(flextime.ZoneResolver{
+		Prefer: map[string][]string{"CST": {"China"}},
+	}))
+	parsed, err := f.Parse("Fri, 21 Nov 1997 09:55:06 CST")
+	require.NoError(t, err)
+	_, offset := parsed.Zone()
+	assert.Equal(t, -6*60*60, offset)
+
+	assert.Equal(t, "Fri, 21 Nov 1997 09:55:06 +0900", f.Format(time.Date(1997, 11, 21, 9, 55, 6, 0, jst)))
+}